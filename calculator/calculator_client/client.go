@@ -2,20 +2,56 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/internal/auth"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/healthcheck"
+	"grpc-go-course/internal/rpcerr"
+	"grpc-go-course/internal/tlsconfig"
+	"grpc-go-course/internal/tracing"
 	"io"
 	"log"
+	"os"
 	"time"
 )
 
 func main() {
+	healthCheck := flag.Bool("healthcheck", false, "only check that CalculatorService is SERVING and exit non-zero otherwise")
+	cfg, error := config.Load(flag.CommandLine, os.Args[1:], config.Options{
+		Role:      config.Client,
+		EnvPrefix: "CALCULATOR",
+		Defaults:  config.ClientDefaults("localhost:50052"),
+	})
+	if error != nil {
+		log.Fatalf("%v", error)
+	}
+
 	fmt.Printf("client started")
 
-	var connection, error = grpc.Dial("localhost:50052", grpc.WithInsecure())
+	transport, error := tlsconfig.DialOption(cfg.TLS)
+	if error != nil {
+		log.Fatalf("failed to set up TLS: %v", error)
+	}
+
+	authOpts, error := auth.DialOptions(cfg.Auth)
+	if error != nil {
+		log.Fatalf("failed to set up authentication: %v", error)
+	}
+
+	tracer, error := tracing.Setup(context.Background(), cfg.Tracing, "calculator_client")
+	if error != nil {
+		log.Fatalf("failed to set up tracing: %v", error)
+	}
+	defer tracer.Shutdown(context.Background())
+
+	opts := append(cfg.DialOptions(), transport)
+	opts = append(opts, tracer.DialOptions()...)
+	connection, error := grpc.Dial(cfg.Target, append(opts, authOpts...)...)
 	if error != nil {
 		log.Fatalf("could not connect: %v", error)
 	}
@@ -27,15 +63,34 @@ func main() {
 		}
 	}(connection)
 
+	if *healthCheck {
+		runHealthCheck(connection)
+		return
+	}
+
 	calculatorServiceClient := calculatorpb.NewCalculatorServiceClient(connection)
 
 	//makeUnaryCall(calculatorServiceClient)
 	//makeServerStreamingCall(calculatorServiceClient)
 	//makeClientStreamingCall(calculatorServiceClient)
+	//makeStatisticsCall(calculatorServiceClient)
 	//makeBidirectionalStreamingCall(calculatorServiceClient)
+	//makeDecimalCall(calculatorServiceClient)
+	//makeEvaluateCall(calculatorServiceClient)
+	//makeBatchCall(calculatorServiceClient)
 	makeErrorUnary(calculatorServiceClient)
 }
 
+func runHealthCheck(connection *grpc.ClientConn) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if code := healthcheck.Run(ctx, connection, "calculator.CalculatorService", os.Stdout, os.Stderr); code != 0 {
+		connection.Close()
+		os.Exit(code)
+	}
+}
+
 func makeUnaryCall(calcServiceClient calculatorpb.CalculatorServiceClient) {
 	fmt.Printf("Starting to do an unary RPC \n")
 
@@ -87,7 +142,14 @@ func makeServerStreamingCall(calcServiceClient calculatorpb.CalculatorServiceCli
 		}
 
 		// handle stream response
-		log.Printf("PrimeFactor: %v\n", message.GetPrimeFactor())
+		switch result := message.GetResult().(type) {
+		case *calculatorpb.PrimeNumberDecompositionResponse_PrimeFactor:
+			log.Printf("PrimeFactor: %v\n", result.PrimeFactor)
+		case *calculatorpb.PrimeNumberDecompositionResponse_BigPrimeFactor:
+			log.Printf("PrimeFactor: %v\n", result.BigPrimeFactor)
+		case *calculatorpb.PrimeNumberDecompositionResponse_Progress:
+			log.Printf("still working after %v: %v candidates tested\n", result.Progress.GetElapsed().AsDuration(), result.Progress.GetCandidatesTested())
+		}
 	}
 
 	fmt.Printf("Closing PrimeDecomposition Server Streaming RPC...\n")
@@ -140,7 +202,7 @@ func makeBidirectionalStreamingCall(c calculatorpb.CalculatorServiceClient) {
 		for _, number := range numbers {
 			fmt.Printf("Sending message: %v \t", number)
 			stream.Send(&calculatorpb.FindMaximumRequest{
-				Number: number,
+				Request: &calculatorpb.FindMaximumRequest_Number{Number: number},
 			})
 			time.Sleep(1000 * time.Millisecond)
 		}
@@ -187,19 +249,105 @@ func makeErrorUnary(c calculatorpb.CalculatorServiceClient) {
 func makeErrorCall(c calculatorpb.CalculatorServiceClient, number int32) {
 	res, err := c.SquareRoot(context.Background(), &calculatorpb.SquareRootRequest{Number: number})
 	if err != nil {
-		respErr, ok := status.FromError(err)
-		if ok {
-			// actual error from gRPC (user error)
-			fmt.Println(respErr.Code())
-			fmt.Printf(respErr.Message())
-			if respErr.Code() == codes.InvalidArgument {
-				fmt.Printf("Probably sent a negative number! \n")
-			}
-		} else {
-			log.Fatalf("error while calling SquareRoot: %v \n", err)
+		if description, ok := rpcerr.Violation(err, "number"); ok {
+			// the request was wrong, sending it again will not help
+			fmt.Printf("invalid number: %v\n", description)
+			return
 		}
+		if delay, ok := rpcerr.RetryDelay(err); ok {
+			fmt.Printf("SquareRoot failed, retry in %v: %v\n", delay, err)
+			return
+		}
+		log.Fatalf("error while calling SquareRoot: %v \n", err)
 	}
 
 	fmt.Printf("The square root of %v: %v \n", number, res.GetNumberRoot())
+}
 
+func makeDecimalCall(c calculatorpb.CalculatorServiceClient) {
+	fmt.Printf("Starting to do a DecimalArithmetic RPC...\n")
+
+	res, err := c.DecimalArithmetic(context.Background(), &calculatorpb.DecimalArithmeticRequest{
+		A:         "9223372036854775807",
+		B:         "0.1",
+		Operation: calculatorpb.DecimalOperation_DECIMAL_DIVIDE,
+		Scale:     proto.Int32(10),
+		Rounding:  calculatorpb.RoundingMode_ROUNDING_HALF_EVEN,
+	})
+	if err != nil {
+		log.Fatalf("error while calling DecimalArithmetic: %v", err)
+	}
+
+	fmt.Printf("9223372036854775807 / 0.1 = %v (exact: %v)\n", res.GetResult(), res.GetExact())
+}
+
+func makeEvaluateCall(c calculatorpb.CalculatorServiceClient) {
+	fmt.Printf("Starting to do an Evaluate RPC...\n")
+
+	expression := "2 * (x + 1) ^ 2 - sqrt(y)"
+	res, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{
+		Expression: expression,
+		Variables:  map[string]float64{"x": 3, "y": 16},
+	})
+	if err != nil {
+		log.Fatalf("error while calling Evaluate: %v", err)
+	}
+
+	fmt.Printf("%v = %v\n", expression, res.GetResult())
+}
+
+func makeStatisticsCall(c calculatorpb.CalculatorServiceClient) {
+	fmt.Printf("Starting to do a ComputeStatistics RPC...\n")
+
+	stream, err := c.ComputeStatistics(context.Background())
+	if err != nil {
+		log.Fatalf("error while calling ComputeStatistics: %v", err)
+	}
+
+	for _, number := range []float64{3, 1.5, 4, 1, 5.5, 9, 2.5, 6} {
+		request := &calculatorpb.ComputeStatisticsRequest{
+			Value: &calculatorpb.ComputeStatisticsRequest_DoubleValue{DoubleValue: number},
+		}
+		if err := stream.Send(request); err != nil {
+			log.Fatalf("error while sending: %v", err)
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Fatalf("error while receiving: %v", err)
+	}
+
+	fmt.Printf("count %v, mean %v, standard deviation %v, median %v\n", res.GetCount(), res.GetMean(), res.GetStandardDeviation(), res.GetMedian())
+}
+
+func makeBatchCall(c calculatorpb.CalculatorServiceClient) {
+	fmt.Printf("Starting to do a BatchCalculate RPC...\n")
+
+	res, err := c.BatchCalculate(context.Background(), &calculatorpb.BatchCalculateRequest{
+		Operations: []*calculatorpb.BatchOperation{
+			{Tag: "sum", Operation: &calculatorpb.BatchOperation_Sum{Sum: &calculatorpb.SumRequest{FirstNumber: 10, SecondNumber: 5}}},
+			{Tag: "sqrt", Operation: &calculatorpb.BatchOperation_SquareRoot{SquareRoot: &calculatorpb.SquareRootRequest{Number: -2}}},
+			{Tag: "factorize", Operation: &calculatorpb.BatchOperation_Factorize{Factorize: &calculatorpb.PrimeNumberDecompositionRequest{Number: 150}}},
+			{Tag: "evaluate", Operation: &calculatorpb.BatchOperation_Evaluate{Evaluate: &calculatorpb.EvaluateRequest{Expression: "2 ^ 10"}}},
+		},
+	})
+	if err != nil {
+		log.Fatalf("error while calling BatchCalculate: %v", err)
+	}
+
+	for _, result := range res.GetResults() {
+		if result.GetError() != nil {
+			err := status.FromProto(result.GetError()).Err()
+			violations := rpcerr.FieldViolations(err)
+			for _, violation := range violations {
+				fmt.Printf("%v: invalid %v: %v\n", result.GetTag(), violation.GetField(), violation.GetDescription())
+			}
+			if len(violations) == 0 {
+				fmt.Printf("%v failed: %v\n", result.GetTag(), err)
+			}
+			continue
+		}
+		fmt.Printf("%v: %v\n", result.GetTag(), result)
+	}
 }
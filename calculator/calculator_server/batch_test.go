@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/calculator/calculatorservice"
+	"grpc-go-course/internal/auth"
+	"grpc-go-course/internal/authz"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/grpctest"
+	"grpc-go-course/internal/rpcerr"
+	"grpc-go-course/internal/validate"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startBatchServer serves a CalculatorService with the given batch settings,
+// running the interceptors of opts before the validation ones.
+func startBatchServer(t *testing.T, batch config.Batch, opts ...grpc.ServerOption) calculatorpb.CalculatorServiceClient {
+	t.Helper()
+
+	s := grpc.NewServer(append(opts, validate.NewInterceptor(validate.Options{}).ServerOptions()...)...)
+	calculatorpb.RegisterCalculatorServiceServer(s, calculatorservice.New(batch))
+
+	return calculatorpb.NewCalculatorServiceClient(grpctest.Dial(t, s))
+}
+
+func sumOperation(tag string, a, b int64) *calculatorpb.BatchOperation {
+	return &calculatorpb.BatchOperation{Tag: tag, Operation: &calculatorpb.BatchOperation_Sum{
+		Sum: &calculatorpb.SumRequest{FirstNumber: a, SecondNumber: b},
+	}}
+}
+
+func factorizeOperation(tag string, n string) *calculatorpb.BatchOperation {
+	return &calculatorpb.BatchOperation{Tag: tag, Operation: &calculatorpb.BatchOperation_Factorize{
+		Factorize: &calculatorpb.PrimeNumberDecompositionRequest{BigNumber: n},
+	}}
+}
+
+func TestBatchCalculate(t *testing.T) {
+	c := startBatchServer(t, config.Batch{Concurrency: 2, MaxOperations: 10})
+
+	res, err := c.BatchCalculate(context.Background(), &calculatorpb.BatchCalculateRequest{
+		Operations: []*calculatorpb.BatchOperation{
+			sumOperation("sum", 40, 2),
+			{Tag: "sqrt", Operation: &calculatorpb.BatchOperation_SquareRoot{SquareRoot: &calculatorpb.SquareRootRequest{Number: 81}}},
+			{Tag: "negative sqrt", Operation: &calculatorpb.BatchOperation_SquareRoot{SquareRoot: &calculatorpb.SquareRootRequest{Number: -1}}},
+			factorizeOperation("factorize", "4611686039902224373"),
+			{Tag: "evaluate", Operation: &calculatorpb.BatchOperation_Evaluate{Evaluate: &calculatorpb.EvaluateRequest{Expression: "2 ^ 10"}}},
+			{Tag: "syntax error", Operation: &calculatorpb.BatchOperation_Evaluate{Evaluate: &calculatorpb.EvaluateRequest{Expression: "2 ^"}}},
+			{Tag: "nothing"},
+			sumOperation("overflow", 1<<62, 1<<62),
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchCalculate failed: %v", err)
+	}
+
+	results := res.GetResults()
+	if len(results) != 8 {
+		t.Fatalf("got %d results, want 8", len(results))
+	}
+	for i, tag := range []string{"sum", "sqrt", "negative sqrt", "factorize", "evaluate", "syntax error", "nothing", "overflow"} {
+		if results[i].GetTag() != tag {
+			t.Errorf("result %d has tag %q, want %q", i, results[i].GetTag(), tag)
+		}
+	}
+
+	if got := results[0].GetSum().GetResult(); got != 42 {
+		t.Errorf("sum = %v, want 42", got)
+	}
+	if got := results[1].GetSquareRoot().GetNumberRoot(); got != 9 {
+		t.Errorf("sqrt = %v, want 9", got)
+	}
+	if got := strings.Join(results[3].GetFactorize().GetPrimeFactors(), " "); got != "2147483647 2147483659" {
+		t.Errorf("factors = %v", got)
+	}
+	if got := results[4].GetEvaluate().GetResult(); got != 1024 {
+		t.Errorf("evaluate = %v, want 1024", got)
+	}
+
+	wantErrors := map[int]codes.Code{2: codes.InvalidArgument, 5: codes.InvalidArgument, 6: codes.InvalidArgument, 7: codes.OutOfRange}
+	for i, want := range wantErrors {
+		if got := codes.Code(results[i].GetError().GetCode()); got != want {
+			t.Errorf("%s: error %v, want %v", results[i].GetTag(), results[i].GetError(), want)
+		}
+	}
+	if _, ok := rpcerr.Violation(status.FromProto(results[5].GetError()).Err(), "expression"); !ok {
+		t.Errorf("the syntax error lost its details: %v", results[5].GetError())
+	}
+}
+
+func TestBatchCalculateTimesOutSlowOperations(t *testing.T) {
+	c := startBatchServer(t, config.Batch{Concurrency: 2, MaxOperations: 10, OperationTimeout: 50 * time.Millisecond})
+
+	res, err := c.BatchCalculate(context.Background(), &calculatorpb.BatchCalculateRequest{
+		Operations: []*calculatorpb.BatchOperation{factorizeOperation("slow", hardNumber), sumOperation("sum", 40, 2)},
+	})
+	if err != nil {
+		t.Fatalf("BatchCalculate failed: %v", err)
+	}
+
+	results := res.GetResults()
+	if got := codes.Code(results[0].GetError().GetCode()); got != codes.DeadlineExceeded {
+		t.Errorf("the slow operation returned %v, want DeadlineExceeded", results[0])
+	}
+	if got := results[1].GetSum().GetResult(); got != 42 {
+		t.Errorf("sum = %v, want 42", results[1])
+	}
+	waitFactorizationExits(t)
+}
+
+func TestBatchCalculateStopsWhenCancelled(t *testing.T) {
+	c := startBatchServer(t, config.Batch{Concurrency: 1, MaxOperations: 10})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var operations []*calculatorpb.BatchOperation
+	for i := 0; i < 5; i++ {
+		operations = append(operations, factorizeOperation("", hardNumber))
+	}
+
+	_, err := c.BatchCalculate(ctx, &calculatorpb.BatchCalculateRequest{Operations: operations})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("BatchCalculate returned %v, want DeadlineExceeded", err)
+	}
+	waitFactorizationExits(t)
+}
+
+// factorInputCount reads the number of observations of the factorization
+// input histogram.
+func factorInputCount(t *testing.T) uint64 {
+	t.Helper()
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(calculatorservice.Collectors()...)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() == "calculator_prime_decomposition_input" {
+			return family.GetMetric()[0].GetHistogram().GetSampleCount()
+		}
+	}
+	return 0
+}
+
+func TestBatchCalculateRecordsTheServiceMetrics(t *testing.T) {
+	c := startBatchServer(t, config.Batch{Concurrency: 2, MaxOperations: 10})
+	negatives, factorizations := negativeRejections(t, "SquareRoot"), factorInputCount(t)
+
+	_, err := c.BatchCalculate(context.Background(), &calculatorpb.BatchCalculateRequest{
+		Operations: []*calculatorpb.BatchOperation{
+			{Tag: "negative sqrt", Operation: &calculatorpb.BatchOperation_SquareRoot{SquareRoot: &calculatorpb.SquareRootRequest{Number: -1}}},
+			factorizeOperation("factorize", "42"),
+			factorizeOperation("factorize again", "43"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchCalculate failed: %v", err)
+	}
+
+	if got := negativeRejections(t, "SquareRoot") - negatives; got != 1 {
+		t.Errorf("counted %v negative SquareRoot operations, want 1", got)
+	}
+	if got := factorInputCount(t) - factorizations; got != 2 {
+		t.Errorf("observed %v factorization inputs, want 2", got)
+	}
+}
+
+func TestBatchCalculateAppliesTheAuthorizationPolicy(t *testing.T) {
+	policy := &authz.Policy{Default: authz.Deny, Rules: []authz.Rule{
+		{Name: "no-factorization-for-etl", Effect: authz.Deny, Subjects: []string{"etl"},
+			Methods: []string{"/calculator.CalculatorService/PrimeNumberDecomposition"}},
+		{Name: "batch-calculator", Effect: authz.Allow, Roles: []string{"batch"},
+			Methods: []string{"/calculator.CalculatorService/*"}},
+	}}
+	etl := &auth.Identity{Subject: "etl", Roles: []string{"batch"}}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(auth.NewContext(ctx, etl), req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &identityStream{ServerStream: ss, ctx: auth.NewContext(ss.Context(), etl)})
+		}),
+	}
+	c := startBatchServer(t, config.Batch{Concurrency: 2, MaxOperations: 10}, append(opts, authz.NewEnforcer(policy, nil).ServerOptions()...)...)
+	operations := []*calculatorpb.BatchOperation{sumOperation("sum", 40, 2), factorizeOperation("factorize", "42")}
+
+	res, err := c.BatchCalculate(context.Background(), &calculatorpb.BatchCalculateRequest{Operations: operations})
+	if err != nil {
+		t.Fatalf("BatchCalculate failed: %v", err)
+	}
+	results := res.GetResults()
+
+	stream, err := c.BatchCalculateStream(context.Background())
+	if err != nil {
+		t.Fatalf("error while calling BatchCalculateStream: %v", err)
+	}
+	for _, operation := range operations {
+		if err := stream.Send(operation); err != nil {
+			t.Fatalf("error while sending: %v", err)
+		}
+	}
+	stream.CloseSend()
+	for {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("error while reading: %v", err)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+	for i := 0; i < len(results); i += 2 {
+		if got := results[i].GetSum().GetResult(); got != 42 {
+			t.Errorf("the allowed operation returned %v", results[i])
+		}
+		if got := codes.Code(results[i+1].GetError().GetCode()); got != codes.PermissionDenied {
+			t.Errorf("the denied operation returned %v, want PermissionDenied", results[i+1])
+		}
+	}
+}
+
+// identityStream replaces the context of a stream with one carrying an identity.
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestBatchCalculateRejectsLargeBatches(t *testing.T) {
+	c := startBatchServer(t, config.Batch{Concurrency: 2, MaxOperations: 2})
+
+	_, err := c.BatchCalculate(context.Background(), &calculatorpb.BatchCalculateRequest{
+		Operations: []*calculatorpb.BatchOperation{sumOperation("a", 1, 1), sumOperation("b", 1, 1), sumOperation("c", 1, 1)},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("BatchCalculate of 3 operations returned %v, want InvalidArgument", err)
+	}
+}
+
+func TestBatchCalculateStreamKeepsTheOrder(t *testing.T) {
+	c := startBatchServer(t, config.Batch{Concurrency: 4, MaxOperations: 10})
+
+	stream, err := c.BatchCalculateStream(context.Background())
+	if err != nil {
+		t.Fatalf("error while calling BatchCalculateStream: %v", err)
+	}
+
+	// the slow factorization comes first, the sums overtake it on the server
+	// but must not in the results
+	operations := []*calculatorpb.BatchOperation{factorizeOperation("slow", "4611686039902224373")}
+	for i := int64(0); i < 20; i++ {
+		operations = append(operations, sumOperation(string(rune('a'+i)), i, i))
+	}
+	for _, operation := range operations {
+		if err := stream.Send(operation); err != nil {
+			t.Fatalf("error while sending: %v", err)
+		}
+	}
+	stream.CloseSend()
+
+	var tags []string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("error while reading: %v", err)
+		}
+		if res.GetError() != nil {
+			t.Errorf("%s failed: %v", res.GetTag(), res.GetError())
+		}
+		tags = append(tags, res.GetTag())
+	}
+
+	var want []string
+	for _, operation := range operations {
+		want = append(want, operation.GetTag())
+	}
+	if strings.Join(tags, ",") != strings.Join(want, ",") {
+		t.Errorf("results came back as %v, want %v", tags, want)
+	}
+}
+
+func TestBatchCalculateStreamPipelines(t *testing.T) {
+	c := startBatchServer(t, config.Batch{Concurrency: 1, MaxOperations: 10})
+
+	stream, err := c.BatchCalculateStream(context.Background())
+	if err != nil {
+		t.Fatalf("error while calling BatchCalculateStream: %v", err)
+	}
+	for i := int64(1); i <= 3; i++ {
+		if err := stream.Send(sumOperation("", i, i)); err != nil {
+			t.Fatalf("error while sending: %v", err)
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("error while reading: %v", err)
+		}
+		if res.GetSum().GetResult() != 2*i {
+			t.Errorf("result %d = %v", i, res)
+		}
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("stream ended with %v, want io.EOF", err)
+	}
+}
+
+func TestBatchCalculateStreamBoundsConcurrency(t *testing.T) {
+	c := startBatchServer(t, config.Batch{Concurrency: 2, MaxOperations: 10})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.BatchCalculateStream(ctx)
+	if err != nil {
+		t.Fatalf("error while calling BatchCalculateStream: %v", err)
+	}
+	for i := 0; i < 6; i++ {
+		if err := stream.Send(factorizeOperation("", hardNumber)); err != nil {
+			t.Fatalf("error while sending: %v", err)
+		}
+	}
+
+	buf := make([]byte, 1<<20)
+	running := func() int {
+		stacks := string(buf[:runtime.Stack(buf, true)])
+		return strings.Count(stacks, "grpc-go-course/calculator/factor.Factorize(")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for running() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d factorizations are running, want 2", running())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i := 0; i < 10; i++ {
+		if n := running(); n > 2 {
+			t.Fatalf("%d factorizations are running with a concurrency of 2", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	waitFactorizationExits(t)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/calculator/calculatorservice"
+	"grpc-go-course/internal/grpcweb"
+	"io"
+	"testing"
+)
+
+func TestPrimeNumberDecompositionOverGRPCWeb(t *testing.T) {
+	s := grpc.NewServer()
+	calculatorpb.RegisterCalculatorServiceServer(s, &calculatorservice.Server{})
+	t.Cleanup(s.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	web, lis, err := grpcweb.Listen("127.0.0.1:0", grpcweb.NewHandler(s, nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go web.Serve(lis)
+	defer web.Close()
+
+	client := &grpcweb.Client{BaseURL: "http://" + web.Addr}
+	stream, err := client.NewStream(ctx, "/calculator.CalculatorService/PrimeNumberDecomposition", &calculatorpb.PrimeNumberDecompositionRequest{Number: 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	var factors []int64
+	for {
+		var res calculatorpb.PrimeNumberDecompositionResponse
+		err := stream.Recv(&res)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("stream failed after %v: %v", factors, err)
+		}
+		factors = append(factors, res.GetPrimeFactor())
+	}
+
+	if len(factors) != 2 || factors[0] != 2 || factors[1] != 7 {
+		t.Errorf("factors = %v, want [2 7]", factors)
+	}
+}
@@ -2,143 +2,115 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"flag"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"grpc-go-course/calculator/calculatorpb"
-	"io"
+	"grpc-go-course/calculator/calculatorservice"
+	"grpc-go-course/internal/auth"
+	"grpc-go-course/internal/authz"
+	"grpc-go-course/internal/bootstrap"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/grpcweb"
+	"grpc-go-course/internal/healthcheck"
+	"grpc-go-course/internal/logging"
+	"grpc-go-course/internal/metrics"
+	"grpc-go-course/internal/tlsconfig"
+	"grpc-go-course/internal/tracing"
+	"grpc-go-course/internal/validate"
 	"log"
-	"math"
+	"log/slog"
 	"net"
-	"time"
+	"os"
 )
 
-type server struct{}
-
-func (s server) SquareRoot(ctx context.Context, request *calculatorpb.SquareRootRequest) (*calculatorpb.SquareRootResponse, error) {
-	fmt.Printf("Received SquareRoot RPC\n")
-	number := request.GetNumber()
-	if number < 0 {
-		return nil, status.Errorf(
-			codes.InvalidArgument,
-			fmt.Sprintf("Received a negative number: %v\n", number),
-		)
+func main() {
+	defaults := config.ServerDefaults("0.0.0.0:50052")
+	defaults.Metrics.Listen = "0.0.0.0:9092"
+	cfg, error := config.Load(flag.CommandLine, os.Args[1:], config.Options{
+		Role:      config.Server,
+		EnvPrefix: "CALCULATOR",
+		Defaults:  defaults,
+	})
+	if error != nil {
+		log.Fatalf("%v", error)
 	}
 
-	return &calculatorpb.SquareRootResponse{
-		NumberRoot: math.Sqrt(float64(number)),
-	}, nil
-
-}
-
-func (s server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServer) error {
-	fmt.Printf("Received FindMaximum streaming RPC\n")
-	numbers := make([]int32, 0)
-	max := int32(0) // smallest integer&non-negative number
-
-	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
-			return nil
-		}
-		if err != nil {
-			log.Fatalf("error while reading client stream: %v", err)
-		}
-
-		inputNumber := req.GetNumber()
-		numbers = append(numbers, inputNumber)
-		if inputNumber > max {
-			max = inputNumber
-		}
-
-		sendErr := stream.Send(&calculatorpb.FindMaximumResponse{
-			Maximum: max,
-		})
-		if sendErr != nil {
-			log.Fatalf("error while sending data to client: %v", sendErr)
-			return sendErr
-		}
+	logger, error := logging.New(os.Stderr, cfg.LogLevel)
+	if error != nil {
+		log.Fatalf("%v", error)
 	}
+	slog.SetDefault(logger)
 
-}
-
-func (s server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAverageServer) error {
-	fmt.Printf("Received ComputeAverage streaming RPC\n")
-	sum := int64(0)
-	length := int64(0)
-	average := 0.0
-
-	for {
-		request, error := stream.Recv()
-		if error == io.EOF {
-			// finished reading the client stream
-			average = float64(sum) / float64(length)
-			return stream.SendAndClose(&calculatorpb.ComputeAverageResponse{
-				Average: average,
-			})
-		}
+	listener, error := net.Listen("tcp", cfg.ListenAddr)
+	if error != nil {
+		log.Fatalf("failed to listen %v", error)
+	}
 
-		// handle error
+	checker := healthcheck.New(calculatorservice.ServiceName)
+	runner := bootstrap.New(bootstrap.Options{
+		Name:         "calculator",
+		DrainTimeout: cfg.DrainTimeout,
+		Health:       checker,
+	})
+
+	recorder := metrics.New()
+	recorder.MustRegister(calculatorservice.Collectors()...)
+	if cfg.Metrics.Listen != "" {
+		metricsServer, error := recorder.ListenAndServe(cfg.Metrics.Listen)
 		if error != nil {
-			log.Fatalf("error while reading client stream: %v\n", error)
+			log.Fatalf("failed to serve metrics: %v", error)
 		}
-
-		sum += request.GetNumber()
-		length = length + 1
+		defer metricsServer.Close()
 	}
-}
-
-func (s server) PrimeNumberDecomposition(request *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
-	fmt.Printf("Received PrimeNumberDecomposition RPC: %v\n", request)
 
-	number := request.GetNumber() // number to be decomposed
-	divisor := int64(2)           // divisor number
+	tracer, error := tracing.Setup(context.Background(), cfg.Tracing, "calculator_server")
+	if error != nil {
+		log.Fatalf("failed to set up tracing: %v", error)
+	}
+	defer tracer.Shutdown(context.Background())
 
-	for number > 1 {
-		remainder := number % divisor
-		if remainder == 0 {
-			response := &calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: divisor}
-			stream.SendMsg(response)
-			time.Sleep(1000 * time.Millisecond)
+	serverTLS, error := tlsconfig.ServerConfig(context.Background(), cfg.TLS)
+	if error != nil {
+		log.Fatalf("failed to set up TLS: %v", error)
+	}
 
-			log.Printf("Factor found: %v", divisor)
-			number = number / divisor
-		} else {
-			divisor++
-			log.Printf("Divisor has increased to %v\n", divisor)
+	opts := append(cfg.ServerOptions(), tlsconfig.ServerOptions(serverTLS)...)
+	opts = append(opts, runner.ServerOptions()...)
+	opts = append(opts, recorder.ServerOptions()...)
+	opts = append(opts, logging.NewInterceptor(logger, logging.Options{
+		Payloads: cfg.LogPayloads,
+		Redact:   cfg.LogRedact,
+	}).ServerOptions()...)
+	opts = append(opts, tracer.ServerOptions()...)
+	if cfg.Auth.Enabled {
+		authenticator, error := auth.New(cfg.Auth)
+		if error != nil {
+			log.Fatalf("failed to set up authentication: %v", error)
 		}
+		opts = append(opts, authenticator.ServerOptions()...)
 	}
-
-	return nil
-}
-
-func (s server) Sum(_ context.Context, request *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
-	fmt.Printf("Sum function was invoked with: %v\n", request)
-
-	firstNumber := request.GetFirstNumber()
-	secondNumber := request.GetSecondNumber()
-
-	result := firstNumber + secondNumber
-
-	response := &calculatorpb.SumResponse{Result: result}
-
-	return response, nil
-}
-
-func main() {
-	fmt.Printf("Staring CalculatorService\n")
-
-	var listener, error = net.Listen("tcp", "0.0.0.0:50052")
-	if error != nil {
-		log.Fatalf("failed to listen %v", error)
+	if cfg.Authz.PolicyFile != "" {
+		policy, error := authz.LoadPolicy(cfg.Authz.PolicyFile)
+		if error != nil {
+			log.Fatalf("failed to set up authorization: %v", error)
+		}
+		opts = append(opts, authz.NewEnforcer(policy, cfg.Auth.PublicMethods).ServerOptions()...)
 	}
+	opts = append(opts, validate.NewInterceptor(validate.Options{Rejected: calculatorservice.CountNegativeSquareRoots}).ServerOptions()...)
 
-	grpcServer := grpc.NewServer()
-	calculatorServiceServer := &server{}
+	grpcServer := grpc.NewServer(opts...)
+	calculatorServiceServer := calculatorservice.New(cfg.Batch)
 	calculatorpb.RegisterCalculatorServiceServer(grpcServer, calculatorServiceServer)
+	bootstrap.RegisterFeatures(grpcServer, cfg.Features, checker)
+	if cfg.GRPCWeb.Listen != "" {
+		webServer, webListener, error := grpcweb.Listen(cfg.GRPCWeb.Listen, grpcweb.NewHandler(grpcServer, cfg.GRPCWeb.AllowedOrigins), serverTLS)
+		if error != nil {
+			log.Fatalf("failed to serve gRPC-Web: %v", error)
+		}
+		runner.AddHTTPServer(webServer, webListener)
+	}
 
-	if error := grpcServer.Serve(listener); error != nil {
+	if error := runner.Serve(grpcServer, listener); error != nil {
 		log.Fatalf("failed to serve: %v\n", error)
 	}
 }
@@ -0,0 +1,849 @@
+package main
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/calculator/calculatorservice"
+	"grpc-go-course/internal/grpctest"
+	"grpc-go-course/internal/rpcerr"
+	"grpc-go-course/internal/validate"
+	"io"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startTestServer serves CalculatorService over an in-memory listener and returns a
+// client together with a channel that receives the error of every finished stream handler.
+func startTestServer(t *testing.T) (calculatorpb.CalculatorServiceClient, <-chan error) {
+	t.Helper()
+
+	handlerErrs := make(chan error, 16)
+	opts := validate.NewInterceptor(validate.Options{}).ServerOptions()
+	s := grpc.NewServer(append(opts, grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		handlerErrs <- err
+		return err
+	}))...)
+	calculatorpb.RegisterCalculatorServiceServer(s, &calculatorservice.Server{})
+
+	return calculatorpb.NewCalculatorServiceClient(grpctest.Dial(t, s)), handlerErrs
+}
+
+func waitHandlerCode(t *testing.T, handlerErrs <-chan error, want codes.Code) {
+	t.Helper()
+
+	select {
+	case err := <-handlerErrs:
+		if got := status.Code(err); got != want {
+			t.Fatalf("handler returned %v (%v), want %v", got, err, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("handler did not return after the client canceled")
+	}
+}
+
+func assertStillServing(t *testing.T, c calculatorpb.CalculatorServiceClient) {
+	t.Helper()
+
+	res, err := c.Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: 10, SecondNumber: 5})
+	if err != nil {
+		t.Fatalf("server stopped serving after a canceled stream: %v", err)
+	}
+	if res.GetResult() != 15 {
+		t.Fatalf("unexpected Sum result %v", res.GetResult())
+	}
+}
+
+func TestComputeAverageSurvivesCanceledClient(t *testing.T) {
+	c, handlerErrs := startTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.ComputeAverage(ctx)
+	if err != nil {
+		t.Fatalf("error while calling ComputeAverage: %v", err)
+	}
+	if err := stream.Send(&calculatorpb.ComputeAverageRequest{Number: 4}); err != nil {
+		t.Fatalf("error while sending: %v", err)
+	}
+	cancel()
+
+	waitHandlerCode(t, handlerErrs, codes.Canceled)
+	assertStillServing(t, c)
+}
+
+func TestFindMaximumSurvivesCanceledClient(t *testing.T) {
+	c, handlerErrs := startTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.FindMaximum(ctx)
+	if err != nil {
+		t.Fatalf("error while calling FindMaximum: %v", err)
+	}
+	if err := stream.Send(&calculatorpb.FindMaximumRequest{Request: &calculatorpb.FindMaximumRequest_Number{Number: 4}}); err != nil {
+		t.Fatalf("error while sending: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("error while reading: %v", err)
+	}
+	cancel()
+
+	waitHandlerCode(t, handlerErrs, codes.Canceled)
+	assertStillServing(t, c)
+}
+
+func TestPrimeNumberDecompositionSurvivesCanceledClient(t *testing.T) {
+	c, handlerErrs := startTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.PrimeNumberDecomposition(ctx, &calculatorpb.PrimeNumberDecompositionRequest{BigNumber: hardNumber})
+	if err != nil {
+		t.Fatalf("error while calling PrimeNumberDecomposition: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("error while reading: %v", err)
+	}
+	cancel()
+
+	waitHandlerCode(t, handlerErrs, codes.Canceled)
+	waitFactorizationExits(t)
+	assertStillServing(t, c)
+}
+
+func TestSumRejectsOverflow(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	tests := []struct {
+		first, second int64
+		want          codes.Code
+	}{
+		{math.MaxInt64, 0, codes.OK},
+		{math.MaxInt64, 1, codes.OutOfRange},
+		{math.MinInt64, -1, codes.OutOfRange},
+		{math.MinInt64, math.MaxInt64, codes.OK},
+		{math.MaxInt64 - 5, 5, codes.OK},
+	}
+
+	for _, tt := range tests {
+		res, err := c.Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: tt.first, SecondNumber: tt.second})
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("Sum(%d, %d) returned %v (%v), want %v", tt.first, tt.second, got, err, tt.want)
+			continue
+		}
+		if _, ok := rpcerr.Violation(err, "second_number"); err != nil && !ok {
+			t.Errorf("Sum(%d, %d) returned no violation of second_number: %v", tt.first, tt.second, err)
+		}
+		if err == nil && res.GetResult() != tt.first+tt.second {
+			t.Errorf("Sum(%d, %d) = %d", tt.first, tt.second, res.GetResult())
+		}
+	}
+}
+
+func TestSquareRootRejectsNegativeNumbers(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	_, err := c.SquareRoot(context.Background(), &calculatorpb.SquareRootRequest{Number: -2})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("SquareRoot(-2) returned %v, want InvalidArgument", err)
+	}
+	if description, ok := rpcerr.Violation(err, "number"); !ok || !strings.Contains(description, "-2") {
+		t.Errorf("SquareRoot(-2) violations = %v, want one on number", rpcerr.FieldViolations(err))
+	}
+	if _, ok := rpcerr.RetryDelay(err); ok {
+		t.Errorf("SquareRoot(-2) is retryable")
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	tests := []struct {
+		name  string
+		req   *calculatorpb.DecimalArithmeticRequest
+		want  string
+		exact bool
+	}{
+		{"add beyond int64", &calculatorpb.DecimalArithmeticRequest{A: "9223372036854775807", B: "1", Operation: calculatorpb.DecimalOperation_DECIMAL_ADD}, "9223372036854775808", true},
+		{"subtract", &calculatorpb.DecimalArithmeticRequest{A: "0.1", B: "0.3", Operation: calculatorpb.DecimalOperation_DECIMAL_SUBTRACT}, "-0.2", true},
+		{"multiply rounded", &calculatorpb.DecimalArithmeticRequest{A: "1.005", B: "1", Operation: calculatorpb.DecimalOperation_DECIMAL_MULTIPLY, Scale: proto.Int32(2), Rounding: calculatorpb.RoundingMode_ROUNDING_HALF_UP}, "1.01", false},
+		{"divide default scale", &calculatorpb.DecimalArithmeticRequest{A: "1", B: "3", Operation: calculatorpb.DecimalOperation_DECIMAL_DIVIDE}, "0.33333333333333333333", false},
+		{"divide floor", &calculatorpb.DecimalArithmeticRequest{A: "-2", B: "3", Operation: calculatorpb.DecimalOperation_DECIMAL_DIVIDE, Scale: proto.Int32(2), Rounding: calculatorpb.RoundingMode_ROUNDING_FLOOR}, "-0.67", false},
+		{"divide exact", &calculatorpb.DecimalArithmeticRequest{A: "10", B: "4", Operation: calculatorpb.DecimalOperation_DECIMAL_DIVIDE, Scale: proto.Int32(1)}, "2.5", true},
+	}
+
+	for _, tt := range tests {
+		res, err := c.DecimalArithmetic(context.Background(), tt.req)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if res.GetResult() != tt.want || res.GetExact() != tt.exact {
+			t.Errorf("%s: got %s exact=%v, want %s exact=%v", tt.name, res.GetResult(), res.GetExact(), tt.want, tt.exact)
+		}
+	}
+}
+
+func TestDecimalArithmeticRejectsInvalidRequests(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	tests := []struct {
+		req   *calculatorpb.DecimalArithmeticRequest
+		field string
+	}{
+		{&calculatorpb.DecimalArithmeticRequest{A: "1", B: "2"}, "operation"},
+		{&calculatorpb.DecimalArithmeticRequest{A: "one", B: "2", Operation: calculatorpb.DecimalOperation_DECIMAL_ADD}, "a"},
+		{&calculatorpb.DecimalArithmeticRequest{A: "1", B: "0", Operation: calculatorpb.DecimalOperation_DECIMAL_DIVIDE}, "b"},
+		{&calculatorpb.DecimalArithmeticRequest{A: "1", B: "3", Operation: calculatorpb.DecimalOperation_DECIMAL_DIVIDE, Scale: proto.Int32(-1)}, "scale"},
+		{&calculatorpb.DecimalArithmeticRequest{A: "1", B: "3", Operation: calculatorpb.DecimalOperation_DECIMAL_DIVIDE, Rounding: 42}, "rounding"},
+	}
+
+	for _, tt := range tests {
+		_, err := c.DecimalArithmetic(context.Background(), tt.req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("DecimalArithmetic(%v) returned %v, want InvalidArgument", tt.req, err)
+		}
+		if _, ok := rpcerr.Violation(err, tt.field); !ok {
+			t.Errorf("DecimalArithmetic(%v) violations = %v, want one on %s", tt.req, rpcerr.FieldViolations(err), tt.field)
+		}
+	}
+}
+
+func TestDecimalSquareRoot(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	res, err := c.DecimalSquareRoot(context.Background(), &calculatorpb.DecimalSquareRootRequest{Number: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetRoot() != "1.41421356237309504880" || res.GetExact() {
+		t.Errorf("sqrt(2) = %s exact=%v", res.GetRoot(), res.GetExact())
+	}
+
+	res, err = c.DecimalSquareRoot(context.Background(), &calculatorpb.DecimalSquareRootRequest{
+		Number:   "123456789012345678901234567890",
+		Scale:    proto.Int32(0),
+		Rounding: calculatorpb.RoundingMode_ROUNDING_DOWN,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetRoot() != "351364182882014" || res.GetExact() {
+		t.Errorf("integer sqrt = %s exact=%v", res.GetRoot(), res.GetExact())
+	}
+
+	if _, err := c.DecimalSquareRoot(context.Background(), &calculatorpb.DecimalSquareRootRequest{Number: "-4"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("sqrt(-4) returned %v, want InvalidArgument", err)
+	}
+}
+
+// negativeRejections reads the negative square root counter of method.
+func negativeRejections(t *testing.T, method string) float64 {
+	t.Helper()
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(calculatorservice.Collectors()...)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != "calculator_square_root_negative_rejections_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "grpc_method" && label.GetValue() == method {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestNegativeSquareRootsAreCountedPerMethod(t *testing.T) {
+	c, _ := startTestServer(t)
+	squareRoots, decimalSquareRoots := negativeRejections(t, "SquareRoot"), negativeRejections(t, "DecimalSquareRoot")
+
+	request := &calculatorpb.SquareRootRequest{Number: -4}
+	calculatorservice.CountNegativeSquareRoots(context.Background(), "/calculator.CalculatorService/SquareRoot", request, validate.Message(request))
+	if _, err := c.DecimalSquareRoot(context.Background(), &calculatorpb.DecimalSquareRootRequest{Number: "-4"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("sqrt(-4) returned %v, want InvalidArgument", err)
+	}
+	if _, err := c.DecimalSquareRoot(context.Background(), &calculatorpb.DecimalSquareRootRequest{Number: "-9"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("sqrt(-9) returned %v, want InvalidArgument", err)
+	}
+
+	if got := negativeRejections(t, "SquareRoot") - squareRoots; got != 1 {
+		t.Errorf("counted %v negative SquareRoot calls, want 1", got)
+	}
+	if got := negativeRejections(t, "DecimalSquareRoot") - decimalSquareRoots; got != 2 {
+		t.Errorf("counted %v negative DecimalSquareRoot calls, want 2", got)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	res, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{
+		Expression: "max(a, b) ^ 2 - sqrt(16) % 3",
+		Variables:  map[string]float64{"a": 2, "b": 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetResult() != 24 {
+		t.Errorf("result = %v, want 24", res.GetResult())
+	}
+}
+
+func TestEvaluateReportsTheColumnOfErrors(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{"2 * (3 + 4", "column 11: "},
+		{"1 + ^ 2", "column 5: "},
+		{"sqrt(x)", `column 6: undefined variable "x"`},
+		{"10 / (2 - 2)", "column 4: division by zero"},
+		{strings.Repeat("1+", 2048) + "1", "at most 4096 characters"},
+	}
+
+	for _, tt := range tests {
+		_, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{Expression: tt.expression})
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("Evaluate(%.20q) returned %v, want InvalidArgument", tt.expression, err)
+			continue
+		}
+
+		violations := rpcerr.FieldViolations(err)
+		if len(violations) != 1 || violations[0].GetField() != "expression" || !strings.Contains(violations[0].GetDescription(), tt.want) {
+			t.Errorf("Evaluate(%.20q) violations = %v, want one on expression containing %q", tt.expression, violations, tt.want)
+		}
+	}
+}
+
+func TestPrimeNumberDecompositionOfLargeNumbers(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	tests := []struct {
+		request *calculatorpb.PrimeNumberDecompositionRequest
+		want    []string
+	}{
+		{&calculatorpb.PrimeNumberDecompositionRequest{Number: 120}, []string{"2", "2", "2", "3", "5"}},
+		{&calculatorpb.PrimeNumberDecompositionRequest{Number: 4611686039902224373}, []string{"2147483647", "2147483659"}},
+		{&calculatorpb.PrimeNumberDecompositionRequest{BigNumber: "1856910058928070412348686333"}, []string{"3", "618970019642690137449562111"}},
+		{&calculatorpb.PrimeNumberDecompositionRequest{BigNumber: "169707852197603080537070037433419722941"}, []string{"274177", "1000003", "618970019642690137449562111"}},
+	}
+
+	for _, tt := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		stream, err := c.PrimeNumberDecomposition(ctx, tt.request)
+		if err != nil {
+			t.Fatalf("error while calling PrimeNumberDecomposition: %v", err)
+		}
+
+		var got []string
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%v: stream failed after %v: %v", tt.request, got, err)
+			}
+			switch result := res.GetResult().(type) {
+			case *calculatorpb.PrimeNumberDecompositionResponse_PrimeFactor:
+				got = append(got, strconv.FormatInt(result.PrimeFactor, 10))
+			case *calculatorpb.PrimeNumberDecompositionResponse_BigPrimeFactor:
+				got = append(got, result.BigPrimeFactor)
+			default:
+				t.Fatalf("unexpected %v without a progress_interval", res)
+			}
+		}
+		cancel()
+
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("%v: factors = %v, want %v", tt.request, got, tt.want)
+		}
+	}
+}
+
+func TestPrimeNumberDecompositionRejectsInvalidInput(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	for _, req := range []*calculatorpb.PrimeNumberDecompositionRequest{
+		{},
+		{Number: 1},
+		{Number: -5},
+		{BigNumber: "1"},
+		{BigNumber: "12x"},
+		{BigNumber: "1.5"},
+		{Number: 6, BigNumber: "6"},
+		{BigNumber: strings.Repeat("9", 1001)},
+	} {
+		stream, err := c.PrimeNumberDecomposition(context.Background(), req)
+		if err != nil {
+			t.Fatalf("error while calling PrimeNumberDecomposition: %v", err)
+		}
+		if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("PrimeNumberDecomposition(%.30v) returned %v, want InvalidArgument", req, err)
+		}
+	}
+}
+
+// hardNumber is 2 × (2^89 - 1) × (2^107 - 1): the 2 is found at once, the
+// rest would keep Pollard's rho busy for days.
+const hardNumber = "200867255532373784442745261218125533716809362059418184712194"
+
+// waitFactorizationExits fails the test unless no goroutine is running the
+// factorization engine anymore.
+func waitFactorizationExits(t *testing.T) {
+	t.Helper()
+
+	buf := make([]byte, 1<<20)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stacks := string(buf[:runtime.Stack(buf, true)])
+		if !strings.Contains(stacks, "grpc-go-course/calculator/factor.") {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the factorization is still running:\n%s", stacks)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPrimeNumberDecompositionReportsProgress(t *testing.T) {
+	c, handlerErrs := startTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.PrimeNumberDecomposition(ctx, &calculatorpb.PrimeNumberDecompositionRequest{
+		BigNumber:        hardNumber,
+		ProgressInterval: durationpb.New(time.Millisecond),
+	})
+	if err != nil {
+		t.Fatalf("error while calling PrimeNumberDecomposition: %v", err)
+	}
+
+	res, err := stream.Recv()
+	if err != nil || res.GetPrimeFactor() != 2 {
+		t.Fatalf("first message = %v, %v, want the factor 2", res, err)
+	}
+
+	// trial division may be over before the first report is due; read on
+	// until Pollard's rho has reported a few times
+	var reports []*calculatorpb.DecompositionProgress
+	for rho := 0; rho < 3; {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("error while reading: %v", err)
+		}
+		report := res.GetProgress()
+		if report == nil {
+			t.Fatalf("unexpected %v, want progress", res)
+		}
+
+		switch report.GetStage() {
+		case calculatorpb.DecompositionProgress_TRIAL_DIVISION:
+			if rho > 0 || report.GetBound() != "65536" {
+				t.Errorf("unexpected trial division report %v", report)
+			}
+		case calculatorpb.DecompositionProgress_POLLARD_RHO:
+			if report.GetCurrent() == "" || report.GetBound() != "" {
+				t.Errorf("unexpected Pollard's rho report %v", report)
+			}
+			rho++
+		default:
+			t.Fatalf("report %v has no stage", report)
+		}
+		if n := len(reports); n > 0 && (report.GetElapsed().AsDuration() <= reports[n-1].GetElapsed().AsDuration() || report.GetCandidatesTested() < reports[n-1].GetCandidatesTested()) {
+			t.Errorf("report %v does not follow %v", report, reports[n-1])
+		}
+		reports = append(reports, report)
+	}
+
+	cancel()
+	waitHandlerCode(t, handlerErrs, codes.Canceled)
+	waitFactorizationExits(t)
+}
+
+func TestPrimeNumberDecompositionStopsAtTheDeadline(t *testing.T) {
+	c, handlerErrs := startTestServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	stream, err := c.PrimeNumberDecomposition(ctx, &calculatorpb.PrimeNumberDecompositionRequest{BigNumber: hardNumber})
+	if err != nil {
+		t.Fatalf("error while calling PrimeNumberDecomposition: %v", err)
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == nil {
+			continue
+		}
+		if status.Code(err) != codes.DeadlineExceeded {
+			t.Fatalf("stream ended with %v, want DeadlineExceeded", err)
+		}
+		break
+	}
+
+	waitHandlerCode(t, handlerErrs, codes.DeadlineExceeded)
+	waitFactorizationExits(t)
+	assertStillServing(t, c)
+}
+
+func TestComputeStatistics(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	stream, err := c.ComputeStatistics(context.Background())
+	if err != nil {
+		t.Fatalf("error while calling ComputeStatistics: %v", err)
+	}
+	requests := []*calculatorpb.ComputeStatisticsRequest{
+		{Percentiles: []float64{10}},
+		{Value: &calculatorpb.ComputeStatisticsRequest_IntValue{IntValue: 2}},
+		{Value: &calculatorpb.ComputeStatisticsRequest_DoubleValue{DoubleValue: 4.5}, Percentiles: []float64{90}},
+		{Value: &calculatorpb.ComputeStatisticsRequest_IntValue{IntValue: -1}},
+		{Value: &calculatorpb.ComputeStatisticsRequest_DoubleValue{DoubleValue: 10.5}},
+	}
+	for _, req := range requests {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("error while sending: %v", err)
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("error while receiving: %v", err)
+	}
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"count", float64(res.GetCount()), 4},
+		{"sum", res.GetSum(), 16},
+		{"mean", res.GetMean(), 4},
+		{"variance", res.GetVariance(), 71.5 / 4},
+		{"standard deviation", res.GetStandardDeviation(), math.Sqrt(71.5 / 4)},
+		{"sample variance", res.GetSampleVariance(), 71.5 / 3},
+		{"sample standard deviation", res.GetSampleStandardDeviation(), math.Sqrt(71.5 / 3)},
+		{"min", res.GetMin(), -1},
+		{"max", res.GetMax(), 10.5},
+		{"median", res.GetMedian(), 3.25},
+	}
+	for _, check := range checks {
+		if math.Abs(check.got-check.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", check.name, check.got, check.want)
+		}
+	}
+
+	percentiles := res.GetPercentiles()
+	if len(percentiles) != 2 || percentiles[0].GetPercentile() != 10 || percentiles[1].GetPercentile() != 90 {
+		t.Fatalf("percentiles = %v, want 10 and 90", percentiles)
+	}
+	if math.Abs(percentiles[0].GetValue()-(-0.1)) > 1e-9 || math.Abs(percentiles[1].GetValue()-8.7) > 1e-9 {
+		t.Errorf("percentiles = %v, want -0.1 and 8.7", percentiles)
+	}
+	if !res.GetExactPercentiles() {
+		t.Errorf("percentiles of 4 values should be exact")
+	}
+}
+
+func TestComputeStatisticsRejectsInvalidStreams(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	tests := map[string][]*calculatorpb.ComputeStatisticsRequest{
+		"empty":                 nil,
+		"only percentiles":      {{Percentiles: []float64{50}}},
+		"NaN":                   {{Value: &calculatorpb.ComputeStatisticsRequest_DoubleValue{DoubleValue: math.NaN()}}},
+		"infinity":              {{Value: &calculatorpb.ComputeStatisticsRequest_DoubleValue{DoubleValue: math.Inf(-1)}}},
+		"percentile above 100":  {{Value: &calculatorpb.ComputeStatisticsRequest_IntValue{IntValue: 1}, Percentiles: []float64{101}}},
+		"percentile below zero": {{Value: &calculatorpb.ComputeStatisticsRequest_IntValue{IntValue: 1}, Percentiles: []float64{-1}}},
+	}
+
+	for name, requests := range tests {
+		stream, err := c.ComputeStatistics(context.Background())
+		if err != nil {
+			t.Fatalf("error while calling ComputeStatistics: %v", err)
+		}
+		for _, req := range requests {
+			if err := stream.Send(req); err != nil {
+				t.Fatalf("%s: error while sending: %v", name, err)
+			}
+		}
+		if _, err := stream.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: ComputeStatistics returned %v, want InvalidArgument", name, err)
+		}
+	}
+}
+
+func TestComputeAverage(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	// empty streams used to divide by zero and answer NaN
+	stream, err := c.ComputeAverage(context.Background())
+	if err != nil {
+		t.Fatalf("error while calling ComputeAverage: %v", err)
+	}
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty ComputeAverage returned %v, want InvalidArgument", err)
+	}
+
+	// and int64 sums used to overflow
+	stream, err = c.ComputeAverage(context.Background())
+	if err != nil {
+		t.Fatalf("error while calling ComputeAverage: %v", err)
+	}
+	for _, n := range []int64{math.MaxInt64, math.MaxInt64} {
+		if err := stream.Send(&calculatorpb.ComputeAverageRequest{Number: n}); err != nil {
+			t.Fatalf("error while sending: %v", err)
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("error while receiving: %v", err)
+	}
+	if res.GetAverage() != math.MaxInt64 {
+		t.Errorf("average = %v, want %v", res.GetAverage(), float64(math.MaxInt64))
+	}
+}
+
+func number(n int32) *calculatorpb.FindMaximumRequest {
+	return &calculatorpb.FindMaximumRequest{Request: &calculatorpb.FindMaximumRequest_Number{Number: n}}
+}
+
+func configure(config *calculatorpb.FindMaximumConfig) *calculatorpb.FindMaximumRequest {
+	return &calculatorpb.FindMaximumRequest{Request: &calculatorpb.FindMaximumRequest_Config{Config: config}}
+}
+
+// findMaximum sends requests and returns the answers of the server.
+func findMaximum(t *testing.T, c calculatorpb.CalculatorServiceClient, requests ...*calculatorpb.FindMaximumRequest) ([]*calculatorpb.FindMaximumResponse, error) {
+	t.Helper()
+
+	stream, err := c.FindMaximum(context.Background())
+	if err != nil {
+		t.Fatalf("error while calling FindMaximum: %v", err)
+	}
+	for _, req := range requests {
+		if err := stream.Send(req); err != nil {
+			break // the server ended the call, Recv reports why
+		}
+	}
+	stream.CloseSend()
+
+	var responses []*calculatorpb.FindMaximumResponse
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return responses, nil
+		}
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, res)
+	}
+}
+
+func TestFindMaximumModes(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	tests := []struct {
+		name     string
+		requests []*calculatorpb.FindMaximumRequest
+		want     []*calculatorpb.FindMaximumResponse
+	}{
+		{
+			"negative numbers without a config",
+			[]*calculatorpb.FindMaximumRequest{number(-5), number(-9), number(-2)},
+			[]*calculatorpb.FindMaximumResponse{{Maximum: -5}, {Maximum: -5}, {Maximum: -2}},
+		},
+		{
+			"global minimum",
+			[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_GLOBAL_MINIMUM}), number(3), number(7), number(1)},
+			[]*calculatorpb.FindMaximumResponse{{Minimum: 3}, {Minimum: 3}, {Minimum: 1}},
+		},
+		{
+			"global maximum, changes only",
+			[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{OnlyChanges: true}), number(1), number(1), number(0), number(4), number(2)},
+			[]*calculatorpb.FindMaximumResponse{{Maximum: 1}, {Maximum: 4}},
+		},
+		{
+			"window of 3",
+			[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_WINDOW_MAXIMUM, WindowSize: 3}), number(9), number(1), number(2), number(3), number(-1), number(-2), number(-3)},
+			[]*calculatorpb.FindMaximumResponse{{Maximum: 9}, {Maximum: 9}, {Maximum: 9}, {Maximum: 3}, {Maximum: 3}, {Maximum: 3}, {Maximum: -1}},
+		},
+		{
+			"top 2, changes only",
+			[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_TOP_K, K: 2, OnlyChanges: true}), number(5), number(1), number(0), number(8), number(3)},
+			[]*calculatorpb.FindMaximumResponse{{Top: []int32{5}}, {Top: []int32{5, 1}}, {Top: []int32{8, 5}}},
+		},
+	}
+
+	for _, tt := range tests {
+		responses, err := findMaximum(t, c, tt.requests...)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		equal := len(responses) == len(tt.want)
+		for i := 0; equal && i < len(responses); i++ {
+			equal = proto.Equal(responses[i], tt.want[i])
+		}
+		if !equal {
+			t.Errorf("%s: responses = %v, want %v", tt.name, responses, tt.want)
+		}
+	}
+}
+
+func TestFindMaximumWindowOverTime(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	stream, err := c.FindMaximum(context.Background())
+	if err != nil {
+		t.Fatalf("error while calling FindMaximum: %v", err)
+	}
+	send := func(req *calculatorpb.FindMaximumRequest) {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("error while sending: %v", err)
+		}
+	}
+	recv := func() int32 {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("error while reading: %v", err)
+		}
+		return res.GetMaximum()
+	}
+
+	send(configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_WINDOW_MAXIMUM, WindowDuration: durationpb.New(500 * time.Millisecond)}))
+	send(number(10))
+	if got := recv(); got != 10 {
+		t.Fatalf("maximum = %d, want 10", got)
+	}
+	send(number(3))
+	if got := recv(); got != 10 {
+		t.Fatalf("maximum = %d, want 10", got)
+	}
+	time.Sleep(600 * time.Millisecond)
+	send(number(1))
+	if got := recv(); got != 1 {
+		t.Errorf("maximum = %d once the window moved past 10 and 3, want 1", got)
+	}
+	stream.CloseSend()
+}
+
+func TestFindMaximumRejectsInvalidConfigs(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	tests := map[string]struct {
+		requests []*calculatorpb.FindMaximumRequest
+		field    string
+	}{
+		"config after numbers": {[]*calculatorpb.FindMaximumRequest{number(1), configure(&calculatorpb.FindMaximumConfig{})}, "config"},
+		"two configs":          {[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{}), configure(&calculatorpb.FindMaximumConfig{})}, "config"},
+		"empty message":        {[]*calculatorpb.FindMaximumRequest{{}}, "request"},
+		"window without bound": {[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_WINDOW_MAXIMUM})}, "config.window_size"},
+		"window too large":     {[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_WINDOW_MAXIMUM, WindowSize: 1<<20 + 1})}, "config.window_size"},
+		"negative duration":    {[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_WINDOW_MAXIMUM, WindowDuration: durationpb.New(-time.Second)})}, "config.window_duration"},
+		"k of 0":               {[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_TOP_K})}, "config.k"},
+		"unknown mode":         {[]*calculatorpb.FindMaximumRequest{configure(&calculatorpb.FindMaximumConfig{Mode: 42})}, "config.mode"},
+	}
+
+	for name, tt := range tests {
+		_, err := findMaximum(t, c, tt.requests...)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: FindMaximum returned %v, want InvalidArgument", name, err)
+		}
+		if _, ok := rpcerr.Violation(err, tt.field); !ok {
+			t.Errorf("%s: FindMaximum violations = %v, want one on %s", name, rpcerr.FieldViolations(err), tt.field)
+		}
+	}
+}
+
+func TestHandlersCheckTheirBoundsWithoutTheInterceptor(t *testing.T) {
+	s := grpc.NewServer()
+	calculatorpb.RegisterCalculatorServiceServer(s, &calculatorservice.Server{})
+	c := calculatorpb.NewCalculatorServiceClient(grpctest.Dial(t, s))
+
+	statistics := func(request *calculatorpb.ComputeStatisticsRequest) error {
+		stream, err := c.ComputeStatistics(context.Background())
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(request); err != nil {
+			return err
+		}
+		_, err = stream.CloseAndRecv()
+		return err
+	}
+	decompose := func(request *calculatorpb.PrimeNumberDecompositionRequest) error {
+		stream, err := c.PrimeNumberDecomposition(context.Background(), request)
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+
+	tests := map[string]struct {
+		call  func() error
+		field string
+	}{
+		"negative square root": {func() error {
+			_, err := c.SquareRoot(context.Background(), &calculatorpb.SquareRootRequest{Number: -2})
+			return err
+		}, "number"},
+		"window too large": {func() error {
+			_, err := findMaximum(t, c, configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_WINDOW_MAXIMUM, WindowSize: 1<<20 + 1}))
+			return err
+		}, "config.window_size"},
+		"negative window duration": {func() error {
+			_, err := findMaximum(t, c, configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_WINDOW_MAXIMUM, WindowDuration: durationpb.New(-time.Second)}))
+			return err
+		}, "config.window_duration"},
+		"k too large": {func() error {
+			_, err := findMaximum(t, c, configure(&calculatorpb.FindMaximumConfig{Mode: calculatorpb.FindMaximumConfig_TOP_K, K: 1001}))
+			return err
+		}, "config.k"},
+		"percentile out of range": {func() error {
+			return statistics(&calculatorpb.ComputeStatisticsRequest{Percentiles: []float64{101}})
+		}, "percentiles"},
+		"infinite value": {func() error {
+			return statistics(&calculatorpb.ComputeStatisticsRequest{Value: &calculatorpb.ComputeStatisticsRequest_DoubleValue{DoubleValue: math.Inf(1)}})
+		}, "double_value"},
+		"expression too long": {func() error {
+			_, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{Expression: strings.Repeat("1+", 2048) + "1"})
+			return err
+		}, "expression"},
+		"big number too long": {func() error {
+			return decompose(&calculatorpb.PrimeNumberDecompositionRequest{BigNumber: strings.Repeat("9", 1001)})
+		}, "big_number"},
+	}
+
+	for name, tt := range tests {
+		err := tt.call()
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: returned %v, want InvalidArgument", name, err)
+			continue
+		}
+		if _, ok := rpcerr.Violation(err, tt.field); !ok {
+			t.Errorf("%s: violations = %v, want one on %s", name, rpcerr.FieldViolations(err), tt.field)
+		}
+	}
+}
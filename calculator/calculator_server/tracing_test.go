@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/calculator/calculatorservice"
+	"grpc-go-course/internal/grpctest"
+	"grpc-go-course/internal/tracing"
+	"testing"
+)
+
+func TestComputeAverageRecordsEveryNumberOnTheServerSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tracing.New(provider)
+
+	s := grpc.NewServer(tracer.ServerOptions()...)
+	calculatorpb.RegisterCalculatorServiceServer(s, &calculatorservice.Server{})
+	conn := grpctest.Dial(t, s, tracer.DialOptions()...)
+
+	// The call runs below an application span, which must become the root of the tree.
+	ctx, root := provider.Tracer("test").Start(context.Background(), "average")
+	stream, err := calculatorpb.NewCalculatorServiceClient(conn).ComputeAverage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int64{1, 2, 3, 4} {
+		if err := stream.Send(&calculatorpb.ComputeAverageRequest{Number: n}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Fatal(err)
+	}
+	root.End()
+	s.GracefulStop()
+
+	spans := make(map[trace.SpanKind]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.SpanKind()] = span
+	}
+	client, srv := spans[trace.SpanKindClient], spans[trace.SpanKindServer]
+	if client == nil || srv == nil {
+		t.Fatalf("recorded spans %v, want a client and a server span", recorder.Ended())
+	}
+
+	if client.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Errorf("client span parent %v, want the application span %v", client.Parent().SpanID(), root.SpanContext().SpanID())
+	}
+	if srv.Parent().SpanID() != client.SpanContext().SpanID() || !srv.Parent().IsRemote() {
+		t.Errorf("server span parent %v, want the remote client span %v", srv.Parent(), client.SpanContext().SpanID())
+	}
+
+	received := 0
+	for _, event := range srv.Events() {
+		for _, attr := range event.Attributes {
+			if attr == semconv.MessageTypeReceived {
+				received++
+			}
+		}
+	}
+	if received != 4 {
+		t.Errorf("server span has %d received message events, want 4", received)
+	}
+	if !hasAttribute(srv.Attributes(), semconv.RPCMethod("ComputeAverage")) {
+		t.Errorf("server span attributes %v lack the rpc method", srv.Attributes())
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
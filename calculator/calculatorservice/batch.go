@@ -0,0 +1,239 @@
+package calculatorservice
+
+import (
+	"context"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/calculator/factor"
+	"grpc-go-course/internal/authz"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/rpcerr"
+	"grpc-go-course/internal/streamerr"
+	"grpc-go-course/internal/validate"
+	"io"
+	"sync"
+)
+
+func (s Server) BatchCalculate(ctx context.Context, request *calculatorpb.BatchCalculateRequest) (*calculatorpb.BatchCalculateResponse, error) {
+	limits := s.batchLimits()
+	operations := request.GetOperations()
+	if len(operations) > limits.MaxOperations {
+		return nil, rpcerr.InvalidArgument("operations", "%d operations, a batch holds at most %d", len(operations), limits.MaxOperations)
+	}
+
+	results := make([]*calculatorpb.BatchResult, len(operations))
+	slots := make(chan struct{}, limits.Concurrency)
+	var wg sync.WaitGroup
+start:
+	for i, operation := range operations {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break start
+		}
+		wg.Add(1)
+		go func(i int, operation *calculatorpb.BatchOperation) {
+			defer wg.Done()
+			results[i] = s.runOperation(ctx, operation)
+			<-slots
+		}(i, operation)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	return &calculatorpb.BatchCalculateResponse{Results: results}, nil
+}
+
+func (s Server) BatchCalculateStream(stream calculatorpb.CalculatorService_BatchCalculateStreamServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// The reader starts each operation as soon as a slot is free and queues
+	// its future result; the handler sends the results in queue order. At
+	// most Concurrency operations run and Concurrency more wait to be sent.
+	concurrency := s.batchLimits().Concurrency
+	slots := make(chan struct{}, concurrency)
+	queue := make(chan chan *calculatorpb.BatchResult, concurrency)
+	readErr := make(chan error, 1)
+
+	go func() {
+		defer close(queue)
+		for {
+			operation, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					readErr <- err
+				}
+				return
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			result := make(chan *calculatorpb.BatchResult, 1)
+			go func() {
+				result <- s.runOperation(ctx, operation)
+				<-slots
+			}()
+			select {
+			case queue <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for result := range queue {
+		if err := stream.Send(<-result); err != nil {
+			return streamerr.Send(stream, err)
+		}
+	}
+	select {
+	case err := <-readErr:
+		return streamerr.Recv(stream, err)
+	default:
+	}
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	return nil
+}
+
+// batchLimits returns the batch settings, or the defaults when the server was
+// built without any.
+func (s Server) batchLimits() config.Batch {
+	if s.batch.Concurrency < 1 || s.batch.MaxOperations < 1 {
+		return config.ServerDefaults("").Batch
+	}
+	return s.batch
+}
+
+// reasonOperationFailed is the ErrorInfo reason of batch items whose handler
+// failed without a status.
+const reasonOperationFailed = "OPERATION_FAILED"
+
+// runOperation runs one batch item through the handler of the matching RPC,
+// turning its error into the result. The item fails with DeadlineExceeded
+// when it runs longer than the operation timeout, so that a slow one cannot
+// hold up the whole batch.
+func (s Server) runOperation(ctx context.Context, operation *calculatorpb.BatchOperation) *calculatorpb.BatchResult {
+	result := &calculatorpb.BatchResult{Tag: operation.GetTag()}
+
+	if timeout := s.batchLimits().OperationTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	method := operationMethod(operation)
+	err := authorizeOperation(ctx, method)
+	if err == nil {
+		err = validateOperation(ctx, method, operation)
+	}
+	if err == nil {
+		switch op := operation.GetOperation().(type) {
+		case *calculatorpb.BatchOperation_Sum:
+			var res *calculatorpb.SumResponse
+			if res, err = s.Sum(ctx, op.Sum); err == nil {
+				result.Result = &calculatorpb.BatchResult_Sum{Sum: res}
+			}
+		case *calculatorpb.BatchOperation_SquareRoot:
+			var res *calculatorpb.SquareRootResponse
+			if res, err = s.SquareRoot(ctx, op.SquareRoot); err == nil {
+				result.Result = &calculatorpb.BatchResult_SquareRoot{SquareRoot: res}
+			}
+		case *calculatorpb.BatchOperation_Factorize:
+			var res *calculatorpb.Factorization
+			if res, err = factorize(ctx, op.Factorize); err == nil {
+				result.Result = &calculatorpb.BatchResult_Factorize{Factorize: res}
+			}
+		case *calculatorpb.BatchOperation_Evaluate:
+			var res *calculatorpb.EvaluateResponse
+			if res, err = s.Evaluate(ctx, op.Evaluate); err == nil {
+				result.Result = &calculatorpb.BatchResult_Evaluate{Evaluate: res}
+			}
+		default:
+			err = rpcerr.InvalidArgument("operation", "operation %q names no operation", operation.GetTag())
+		}
+	}
+
+	if _, ok := status.FromError(err); err != nil && !ok {
+		err = rpcerr.Internal(ServiceName, reasonOperationFailed, map[string]string{"tag": operation.GetTag()}, "operation %q failed: %v", operation.GetTag(), err)
+	}
+	if err != nil {
+		result.Result = &calculatorpb.BatchResult_Error{Error: status.Convert(err).Proto()}
+	}
+	return result
+}
+
+// operationMethod returns the full name of the RPC the operation stands for,
+// or "" when it names no operation.
+func operationMethod(operation *calculatorpb.BatchOperation) string {
+	var method string
+	switch operation.GetOperation().(type) {
+	case *calculatorpb.BatchOperation_Sum:
+		method = "Sum"
+	case *calculatorpb.BatchOperation_SquareRoot:
+		method = "SquareRoot"
+	case *calculatorpb.BatchOperation_Factorize:
+		method = "PrimeNumberDecomposition"
+	case *calculatorpb.BatchOperation_Evaluate:
+		method = "Evaluate"
+	default:
+		return ""
+	}
+	return "/" + ServiceName + "/" + method
+}
+
+// authorizeOperation applies the authorization policy to the RPC the operation
+// stands for, so that a batch cannot do what its caller may not do directly.
+func authorizeOperation(ctx context.Context, method string) error {
+	if method == "" {
+		return nil
+	}
+	return authz.Authorize(ctx, method)
+}
+
+// validateOperation applies the validation rules to the request of the
+// operation, which the interceptor skips so that an invalid operation only
+// fails its own result. Rejections are counted as the interceptor of the
+// server counts them for the RPC.
+func validateOperation(ctx context.Context, method string, operation *calculatorpb.BatchOperation) error {
+	m := operation.ProtoReflect()
+	field := m.WhichOneof(m.Descriptor().Oneofs().ByName("operation"))
+	if field == nil {
+		return nil
+	}
+	request := m.Get(field).Message().Interface()
+	err := validate.Message(request)
+	if err != nil {
+		CountNegativeSquareRoots(ctx, method, request, err)
+	}
+	return err
+}
+
+// factorize is PrimeNumberDecomposition with all factors at once.
+func factorize(ctx context.Context, request *calculatorpb.PrimeNumberDecompositionRequest) (*calculatorpb.Factorization, error) {
+	number, err := decompositionInput(request)
+	if err != nil {
+		return nil, err
+	}
+	observeFactorInput(number)
+
+	factors, err := factor.Factors(ctx, number)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	res := &calculatorpb.Factorization{}
+	for _, p := range factors {
+		res.PrimeFactors = append(res.PrimeFactors, p.String())
+	}
+	return res, nil
+}
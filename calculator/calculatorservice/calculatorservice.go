@@ -0,0 +1,541 @@
+// Package calculatorservice implements CalculatorService, served by
+// calculator_server and reached through the REST gateway.
+package calculatorservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/calculator/decimal"
+	"grpc-go-course/calculator/expr"
+	"grpc-go-course/calculator/extrema"
+	"grpc-go-course/calculator/factor"
+	"grpc-go-course/calculator/stats"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/logging"
+	"grpc-go-course/internal/rpcerr"
+	"grpc-go-course/internal/streamerr"
+	"grpc-go-course/internal/validate"
+	"io"
+	"log/slog"
+	"math"
+	"math/big"
+	"time"
+	"unicode/utf8"
+)
+
+// ServiceName is the full name of CalculatorService, as reported by health checks.
+const ServiceName = "calculator.CalculatorService"
+
+var (
+	factorInputs = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "calculator_prime_decomposition_input",
+		Help:    "Numbers passed to PrimeNumberDecomposition.",
+		Buckets: prometheus.ExponentialBuckets(10, 10, 18),
+	})
+	negativeSquareRoots = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "calculator_square_root_negative_rejections_total",
+		Help: "SquareRoot and DecimalSquareRoot calls rejected because the number was negative, by method.",
+	}, []string{"grpc_method"})
+)
+
+// Server implements calculatorpb.CalculatorServiceServer.
+type Server struct {
+	batch config.Batch
+}
+
+// New creates a Server that processes batches within the limits of batch.
+func New(batch config.Batch) *Server {
+	return &Server{batch: batch}
+}
+
+// Collectors returns the domain metrics of the service, to be registered with
+// the metrics recorder.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{factorInputs, negativeSquareRoots}
+}
+
+func (s Server) SquareRoot(_ context.Context, request *calculatorpb.SquareRootRequest) (*calculatorpb.SquareRootResponse, error) {
+	// checked by the validation rules too, see package validate
+	if request.GetNumber() < 0 {
+		negativeSquareRoots.WithLabelValues("SquareRoot").Inc()
+		return nil, rpcerr.InvalidArgument("number", "must be at least 0, got %d", request.GetNumber())
+	}
+	return &calculatorpb.SquareRootResponse{
+		NumberRoot: math.Sqrt(float64(request.GetNumber())),
+	}, nil
+}
+
+func (s Server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServer) error {
+	var track maximumTracker
+	var onlyChanges bool
+	var last *calculatorpb.FindMaximumResponse
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return streamerr.Recv(stream, err)
+		}
+
+		var inputNumber int32
+		switch request := req.GetRequest().(type) {
+		case *calculatorpb.FindMaximumRequest_Config:
+			if track != nil {
+				return rpcerr.InvalidArgument("config", "config must be the first and only configuration message")
+			}
+			if track, err = newMaximumTracker(request.Config); err != nil {
+				return err
+			}
+			onlyChanges = request.Config.GetOnlyChanges()
+			continue
+		case *calculatorpb.FindMaximumRequest_Number:
+			inputNumber = request.Number
+		default:
+			return rpcerr.InvalidArgument("request", "message carries neither a number nor a config")
+		}
+
+		if track == nil {
+			track, _ = newMaximumTracker(&calculatorpb.FindMaximumConfig{})
+		}
+		response := track(inputNumber, time.Now())
+		if onlyChanges && proto.Equal(response, last) {
+			continue
+		}
+		last = response
+
+		sendErr := stream.Send(response)
+		if sendErr != nil {
+			return streamerr.Send(stream, sendErr)
+		}
+	}
+}
+
+var (
+	// maxWindowSize is the largest window_size the validation rules allow,
+	// which also bounds the windows given by window_duration only.
+	maxWindowSize = int32(validate.Rules(&calculatorpb.FindMaximumConfig{}, "window_size").GetMax())
+	// maxTopK is the largest k the validation rules allow.
+	maxTopK = int32(validate.Rules(&calculatorpb.FindMaximumConfig{}, "k").GetMax())
+)
+
+// maximumTracker folds each number of a FindMaximum stream into the answer.
+type maximumTracker func(number int32, at time.Time) *calculatorpb.FindMaximumResponse
+
+func newMaximumTracker(config *calculatorpb.FindMaximumConfig) (maximumTracker, error) {
+	switch config.GetMode() {
+	case calculatorpb.FindMaximumConfig_GLOBAL_MAXIMUM:
+		max := int32(math.MinInt32)
+		return func(number int32, _ time.Time) *calculatorpb.FindMaximumResponse {
+			if number > max {
+				max = number
+			}
+			return &calculatorpb.FindMaximumResponse{Maximum: max}
+		}, nil
+
+	case calculatorpb.FindMaximumConfig_GLOBAL_MINIMUM:
+		min := int32(math.MaxInt32)
+		return func(number int32, _ time.Time) *calculatorpb.FindMaximumResponse {
+			if number < min {
+				min = number
+			}
+			return &calculatorpb.FindMaximumResponse{Minimum: min}
+		}, nil
+
+	case calculatorpb.FindMaximumConfig_WINDOW_MAXIMUM:
+		// checked by the validation rules too, see package validate
+		size, duration := config.GetWindowSize(), config.GetWindowDuration().AsDuration()
+		if size < 0 || size > maxWindowSize {
+			return nil, rpcerr.InvalidArgument("config.window_size", "must be between 1 and %d, got %d", maxWindowSize, size)
+		}
+		if duration < 0 {
+			return nil, rpcerr.InvalidArgument("config.window_duration", "must not be negative, got %v", duration)
+		}
+		if size == 0 && duration == 0 {
+			return nil, rpcerr.InvalidArgument("config.window_size", "WINDOW_MAXIMUM needs a window_size, a window_duration or both")
+		}
+		if size == 0 {
+			size = maxWindowSize
+		}
+		window := extrema.NewWindow(int(size), duration)
+		return func(number int32, at time.Time) *calculatorpb.FindMaximumResponse {
+			return &calculatorpb.FindMaximumResponse{Maximum: int32(window.Push(int64(number), at))}
+		}, nil
+
+	case calculatorpb.FindMaximumConfig_TOP_K:
+		// the validation rules bound k, but allow the 0 of the other modes
+		k := config.GetK()
+		if k < 1 || k > maxTopK {
+			return nil, rpcerr.InvalidArgument("config.k", "TOP_K needs a k between 1 and %d, got %d", maxTopK, k)
+		}
+		top := extrema.NewTopK(int(k))
+		return func(number int32, _ time.Time) *calculatorpb.FindMaximumResponse {
+			top.Push(int64(number))
+			response := &calculatorpb.FindMaximumResponse{}
+			for _, v := range top.Values() {
+				response.Top = append(response.Top, int32(v))
+			}
+			return response
+		}, nil
+
+	default:
+		return nil, rpcerr.InvalidArgument("config.mode", "unknown mode %v", config.GetMode())
+	}
+}
+
+func (s Server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAverageServer) error {
+	// a running mean rather than a sum, which would overflow int64
+	var summary stats.Summary
+
+	for {
+		request, error := stream.Recv()
+		if error == io.EOF {
+			// finished reading the client stream
+			if summary.Count() == 0 {
+				return rpcerr.InvalidArgument("number", "no numbers received, the average of nothing is undefined")
+			}
+			return stream.SendAndClose(&calculatorpb.ComputeAverageResponse{
+				Average: summary.Mean(),
+			})
+		}
+
+		// handle error
+		if error != nil {
+			return streamerr.Recv(stream, error)
+		}
+
+		summary.Add(float64(request.GetNumber()))
+	}
+}
+
+// defaultPercentiles are reported by ComputeStatistics when the client asks for none.
+var defaultPercentiles = []float64{25, 50, 75, 90, 95, 99}
+
+func (s Server) ComputeStatistics(stream calculatorpb.CalculatorService_ComputeStatisticsServer) error {
+	var summary stats.Summary
+	var distribution stats.Distribution
+	var percentiles []float64
+
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return streamerr.Recv(stream, err)
+		}
+
+		// checked by the validation rules too, see package validate
+		for _, p := range request.GetPercentiles() {
+			if !(p >= 0 && p <= 100) {
+				return rpcerr.InvalidArgument("percentiles", "percentile %v is not between 0 and 100", p)
+			}
+		}
+		percentiles = append(percentiles, request.GetPercentiles()...)
+
+		var value float64
+		switch v := request.GetValue().(type) {
+		case *calculatorpb.ComputeStatisticsRequest_DoubleValue:
+			if math.IsNaN(v.DoubleValue) || math.IsInf(v.DoubleValue, 0) {
+				return rpcerr.InvalidArgument("double_value", "must be a finite number, got %v", v.DoubleValue)
+			}
+			value = v.DoubleValue
+		case *calculatorpb.ComputeStatisticsRequest_IntValue:
+			value = float64(v.IntValue)
+		default:
+			// a message that only asks for percentiles
+			continue
+		}
+		summary.Add(value)
+		distribution.Add(value)
+	}
+
+	if summary.Count() == 0 {
+		return rpcerr.InvalidArgument("value", "no values received")
+	}
+	if len(percentiles) == 0 {
+		percentiles = defaultPercentiles
+	}
+
+	response := &calculatorpb.ComputeStatisticsResponse{
+		Count:                   summary.Count(),
+		Sum:                     summary.Sum(),
+		Mean:                    summary.Mean(),
+		Variance:                summary.Variance(),
+		StandardDeviation:       summary.StdDev(),
+		SampleVariance:          summary.SampleVariance(),
+		SampleStandardDeviation: summary.SampleStdDev(),
+		Min:                     summary.Min(),
+		Max:                     summary.Max(),
+		Median:                  distribution.Quantile(0.5),
+		ExactPercentiles:        distribution.Exact(),
+	}
+	for _, p := range percentiles {
+		response.Percentiles = append(response.Percentiles, &calculatorpb.ComputeStatisticsResponse_Percentile{
+			Percentile: p,
+			Value:      distribution.Quantile(p / 100),
+		})
+	}
+
+	return stream.SendAndClose(response)
+}
+
+// minProgressInterval is the shortest progress_interval PrimeNumberDecomposition honors.
+const minProgressInterval = 10 * time.Millisecond
+
+var progressStages = map[factor.Stage]calculatorpb.DecompositionProgress_Stage{
+	factor.TrialDivision: calculatorpb.DecompositionProgress_TRIAL_DIVISION,
+	factor.Rho:           calculatorpb.DecompositionProgress_POLLARD_RHO,
+}
+
+func (s Server) PrimeNumberDecomposition(request *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
+	number, err := decompositionInput(request)
+	if err != nil {
+		return err
+	}
+	observeFactorInput(number)
+
+	ctx := stream.Context()
+	start := time.Now()
+	lastSent := start
+
+	found := func(prime *big.Int) error {
+		response := &calculatorpb.PrimeNumberDecompositionResponse{}
+		if prime.IsInt64() {
+			response.Result = &calculatorpb.PrimeNumberDecompositionResponse_PrimeFactor{PrimeFactor: prime.Int64()}
+		} else {
+			response.Result = &calculatorpb.PrimeNumberDecompositionResponse_BigPrimeFactor{BigPrimeFactor: prime.String()}
+		}
+		if err := stream.Send(response); err != nil {
+			return streamerr.Send(stream, err)
+		}
+		lastSent = time.Now()
+
+		logging.FromContext(ctx).Debug("factor found", slog.String("factor", prime.String()))
+		return nil
+	}
+
+	var progress func(factor.Progress) error
+	if request.GetProgressInterval() != nil {
+		interval := request.GetProgressInterval().AsDuration()
+		if interval < minProgressInterval {
+			interval = minProgressInterval
+		}
+		progress = func(p factor.Progress) error {
+			now := time.Now()
+			if now.Sub(lastSent) < interval {
+				return nil
+			}
+			report := &calculatorpb.DecompositionProgress{
+				Stage:            progressStages[p.Stage],
+				Current:          p.Current.String(),
+				Elapsed:          durationpb.New(now.Sub(start)),
+				CandidatesTested: p.Tested,
+			}
+			if p.Bound != nil {
+				report.Bound = p.Bound.String()
+			}
+			err := stream.Send(&calculatorpb.PrimeNumberDecompositionResponse{
+				Result: &calculatorpb.PrimeNumberDecompositionResponse_Progress{Progress: report},
+			})
+			if err != nil {
+				return streamerr.Send(stream, err)
+			}
+			lastSent = now
+			return nil
+		}
+	}
+
+	err = factor.FactorizeWithProgress(ctx, number, found, progress)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		logging.FromContext(ctx).Info("decomposition stopped", slog.String("number", number.String()), slog.Any("error", err))
+		return status.FromContextError(err).Err()
+	}
+
+	return err
+}
+
+// maxBigNumberDigits is the longest big_number the validation rules allow.
+var maxBigNumberDigits = int(validate.Rules(&calculatorpb.PrimeNumberDecompositionRequest{}, "big_number").GetMaxLen())
+
+// decompositionInput returns the number to decompose, number or big_number,
+// which must be at least 2 for the decomposition to say anything.
+func decompositionInput(request *calculatorpb.PrimeNumberDecompositionRequest) (*big.Int, error) {
+	text := request.GetBigNumber()
+	// checked by the validation rules too, see package validate
+	if len(text) > maxBigNumberDigits {
+		return nil, rpcerr.InvalidArgument("big_number", "must be at most %d characters long, got %d", maxBigNumberDigits, len(text))
+	}
+	if text == "" {
+		if request.GetNumber() < 2 {
+			return nil, rpcerr.InvalidArgument("number", "must be at least 2, got %d", request.GetNumber())
+		}
+		return big.NewInt(request.GetNumber()), nil
+	}
+	if request.GetNumber() != 0 {
+		return nil, rpcerr.BadRequest(codes.InvalidArgument, "set either number or big_number, not both",
+			rpcerr.Field("number", "must be 0 when big_number is set"),
+			rpcerr.Field("big_number", "must be empty when number is set"),
+		)
+	}
+
+	number, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		return nil, rpcerr.InvalidArgument("big_number", "big_number %q is not a decimal integer", text)
+	}
+	if number.Cmp(big.NewInt(2)) < 0 {
+		return nil, rpcerr.InvalidArgument("big_number", "must be at least 2, got %v", number)
+	}
+	return number, nil
+}
+
+func (s Server) Sum(_ context.Context, request *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
+	firstNumber := request.GetFirstNumber()
+	secondNumber := request.GetSecondNumber()
+
+	if (secondNumber > 0 && firstNumber > math.MaxInt64-secondNumber) || (secondNumber < 0 && firstNumber < math.MinInt64-secondNumber) {
+		return nil, rpcerr.BadRequest(codes.OutOfRange,
+			fmt.Sprintf("%d + %d overflows int64, use DecimalArithmetic for big values", firstNumber, secondNumber),
+			rpcerr.Field("first_number", "the sum overflows int64"),
+			rpcerr.Field("second_number", "the sum overflows int64"),
+		)
+	}
+	result := firstNumber + secondNumber
+
+	response := &calculatorpb.SumResponse{Result: result}
+
+	return response, nil
+}
+
+// maxExpressionLength is the longest expression the validation rules allow.
+var maxExpressionLength = int(validate.Rules(&calculatorpb.EvaluateRequest{}, "expression").GetMaxLen())
+
+func (s Server) Evaluate(_ context.Context, request *calculatorpb.EvaluateRequest) (*calculatorpb.EvaluateResponse, error) {
+	// checked by the validation rules too, see package validate
+	if n := utf8.RuneCountInString(request.GetExpression()); n > maxExpressionLength {
+		return nil, rpcerr.InvalidArgument("expression", "must be at most %d characters long, got %d", maxExpressionLength, n)
+	}
+	parsed, err := expr.Parse(request.GetExpression())
+	if err != nil {
+		return nil, expressionError(err.Error())
+	}
+	result, err := parsed.Eval(request.GetVariables())
+	if err != nil {
+		return nil, expressionError(err.Error())
+	}
+
+	return &calculatorpb.EvaluateResponse{Result: result}, nil
+}
+
+// expressionError is an InvalidArgument status whose BadRequest detail
+// describes what is wrong with the expression field.
+func expressionError(description string) error {
+	return rpcerr.BadRequest(codes.InvalidArgument, "invalid expression: "+description, rpcerr.Field("expression", description))
+}
+
+// defaultDecimalScale is the number of digits after the decimal point of
+// quotients and roots when the request does not ask for a scale.
+const defaultDecimalScale = 20
+
+var roundingModes = map[calculatorpb.RoundingMode]decimal.RoundingMode{
+	calculatorpb.RoundingMode_ROUNDING_HALF_EVEN: decimal.HalfEven,
+	calculatorpb.RoundingMode_ROUNDING_HALF_UP:   decimal.HalfUp,
+	calculatorpb.RoundingMode_ROUNDING_HALF_DOWN: decimal.HalfDown,
+	calculatorpb.RoundingMode_ROUNDING_UP:        decimal.Up,
+	calculatorpb.RoundingMode_ROUNDING_DOWN:      decimal.Down,
+	calculatorpb.RoundingMode_ROUNDING_CEILING:   decimal.Ceiling,
+	calculatorpb.RoundingMode_ROUNDING_FLOOR:     decimal.Floor,
+}
+
+func (s Server) DecimalArithmetic(_ context.Context, request *calculatorpb.DecimalArithmeticRequest) (*calculatorpb.DecimalArithmeticResponse, error) {
+	a, err := decimal.Parse(request.GetA())
+	if err != nil {
+		return nil, rpcerr.InvalidArgument("a", "a: %v", err)
+	}
+	b, err := decimal.Parse(request.GetB())
+	if err != nil {
+		return nil, rpcerr.InvalidArgument("b", "b: %v", err)
+	}
+	mode, ok := roundingModes[request.GetRounding()]
+	if !ok {
+		return nil, rpcerr.InvalidArgument("rounding", "unknown rounding mode %v", request.GetRounding())
+	}
+
+	var result decimal.Decimal
+	switch request.GetOperation() {
+	case calculatorpb.DecimalOperation_DECIMAL_ADD:
+		result = decimal.Add(a, b)
+	case calculatorpb.DecimalOperation_DECIMAL_SUBTRACT:
+		result = decimal.Sub(a, b)
+	case calculatorpb.DecimalOperation_DECIMAL_MULTIPLY:
+		result = decimal.Mul(a, b)
+	case calculatorpb.DecimalOperation_DECIMAL_DIVIDE:
+		scale := defaultDecimalScale
+		if request.Scale != nil {
+			scale = int(request.GetScale())
+		}
+		quotient, exact, err := decimal.Quo(a, b, scale, mode)
+		if err != nil {
+			return nil, rpcerr.InvalidArgument("b", "%v", err)
+		}
+		return &calculatorpb.DecimalArithmeticResponse{Result: quotient.String(), Exact: exact}, nil
+	default:
+		return nil, rpcerr.InvalidArgument("operation", "unsupported operation %v", request.GetOperation())
+	}
+
+	exact := true
+	if request.Scale != nil {
+		result, exact = result.Round(int(request.GetScale()), mode)
+	}
+
+	return &calculatorpb.DecimalArithmeticResponse{Result: result.String(), Exact: exact}, nil
+}
+
+func (s Server) DecimalSquareRoot(ctx context.Context, request *calculatorpb.DecimalSquareRootRequest) (*calculatorpb.DecimalSquareRootResponse, error) {
+	number, err := decimal.Parse(request.GetNumber())
+	if err != nil {
+		return nil, rpcerr.InvalidArgument("number", "number: %v", err)
+	}
+	mode, ok := roundingModes[request.GetRounding()]
+	if !ok {
+		return nil, rpcerr.InvalidArgument("rounding", "unknown rounding mode %v", request.GetRounding())
+	}
+	scale := defaultDecimalScale
+	if request.Scale != nil {
+		scale = int(request.GetScale())
+	}
+
+	root, exact, err := decimal.Sqrt(number, scale, mode)
+	if err == decimal.ErrNegativeSquareRoot {
+		negativeSquareRoots.WithLabelValues("DecimalSquareRoot").Inc()
+		logging.FromContext(ctx).Info("rejected negative number", slog.String("number", number.String()))
+	}
+	if err != nil {
+		return nil, rpcerr.InvalidArgument("number", "%v", err)
+	}
+
+	return &calculatorpb.DecimalSquareRootResponse{Root: root.String(), Exact: exact}, nil
+}
+
+// observeFactorInput records a number about to be factorized.
+func observeFactorInput(number *big.Int) {
+	inputSize, _ := new(big.Float).SetInt(number).Float64()
+	factorInputs.Observe(inputSize)
+}
+
+// CountNegativeSquareRoots counts the SquareRoot calls that the validation
+// rules reject because of the number. It is meant for validate.Options.Rejected
+// and also counts the rejected SquareRoot operations of batches.
+func CountNegativeSquareRoots(_ context.Context, method string, _ proto.Message, err error) {
+	if _, ok := rpcerr.Violation(err, "number"); ok && method == "/"+ServiceName+"/SquareRoot" {
+		negativeSquareRoots.WithLabelValues("SquareRoot").Inc()
+	}
+}
@@ -0,0 +1,297 @@
+// Package decimal implements exact decimal arithmetic on values of any size.
+// A Decimal is an integer together with the number of digits after the
+// decimal point, so 12.50 is 1250 with scale 2. Adding, subtracting and
+// multiplying are always exact; dividing and taking square roots produce a
+// result of the requested scale, rounded with one of the usual rounding modes.
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MaxDigits bounds the number of digits of parsed values and of requested
+// scales, so that a single request cannot make the server allocate without limit.
+const MaxDigits = 10000
+
+var (
+	// ErrDivisionByZero is returned by Quo for a zero divisor.
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrNegativeSquareRoot is returned by Sqrt for negative values.
+	ErrNegativeSquareRoot = errors.New("square root of a negative number")
+)
+
+// RoundingMode decides which neighbour a result is rounded to when it does
+// not fit the requested scale.
+type RoundingMode int
+
+const (
+	// HalfEven rounds to the nearest neighbour and ties to the even one.
+	HalfEven RoundingMode = iota
+	// HalfUp rounds to the nearest neighbour and ties away from zero.
+	HalfUp
+	// HalfDown rounds to the nearest neighbour and ties toward zero.
+	HalfDown
+	// Up rounds away from zero.
+	Up
+	// Down rounds toward zero, i.e. truncates.
+	Down
+	// Ceiling rounds toward positive infinity.
+	Ceiling
+	// Floor rounds toward negative infinity.
+	Floor
+)
+
+var ten = big.NewInt(10)
+
+// Decimal is the value unscaled × 10^-scale. The zero Decimal is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// New returns unscaled × 10^-scale.
+func New(unscaled *big.Int, scale int) Decimal {
+	return Decimal{unscaled: new(big.Int).Set(unscaled), scale: scale}
+}
+
+// Parse reads a decimal string such as "42", "-0.125", "+1.5e-3" or "6.02E23".
+func Parse(s string) (Decimal, error) {
+	text := s
+	mantissa, exponent := text, 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(text[i+1:])
+		if err != nil || exp > MaxDigits || exp < -MaxDigits {
+			return Decimal{}, fmt.Errorf("invalid exponent in %q", s)
+		}
+		mantissa, exponent = text[:i], exp
+	}
+
+	sign := ""
+	if strings.HasPrefix(mantissa, "-") || strings.HasPrefix(mantissa, "+") {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+	}
+
+	digits := intPart + fracPart
+	if digits == "" || !isDigits(digits) {
+		return Decimal{}, fmt.Errorf("%q is not a decimal number", s)
+	}
+	if len(digits) > MaxDigits {
+		return Decimal{}, fmt.Errorf("%q has more than %d digits", truncate(s), MaxDigits)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%q is not a decimal number", s)
+	}
+	if sign == "-" {
+		unscaled.Neg(unscaled)
+	}
+
+	d := Decimal{unscaled: unscaled, scale: len(fracPart) - exponent}
+	if d.scale < 0 {
+		// Keep the scale non-negative, 1e3 is stored as 1000.
+		d.unscaled.Mul(d.unscaled, pow10(-d.scale))
+		d.scale = 0
+	}
+
+	return d, nil
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or 1.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// String formats d without an exponent, keeping trailing zeros of the scale.
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", -d.scale)
+	}
+
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - d.scale
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Add returns a + b.
+func Add(a Decimal, b Decimal) Decimal {
+	a, b = align(a, b)
+	return Decimal{unscaled: new(big.Int).Add(a.int(), b.int()), scale: a.scale}
+}
+
+// Sub returns a - b.
+func Sub(a Decimal, b Decimal) Decimal {
+	a, b = align(a, b)
+	return Decimal{unscaled: new(big.Int).Sub(a.int(), b.int()), scale: a.scale}
+}
+
+// Mul returns a × b.
+func Mul(a Decimal, b Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(a.int(), b.int()), scale: a.scale + b.scale}
+}
+
+// Quo returns a / b with scale digits after the decimal point. exact reports
+// whether no rounding was necessary.
+func Quo(a Decimal, b Decimal, scale int, mode RoundingMode) (result Decimal, exact bool, err error) {
+	if b.Sign() == 0 {
+		return Decimal{}, false, ErrDivisionByZero
+	}
+
+	// a/b × 10^scale = a.unscaled × 10^(scale + b.scale - a.scale) / b.unscaled
+	num, den := new(big.Int).Set(a.int()), new(big.Int).Set(b.int())
+	if e := scale + b.scale - a.scale; e >= 0 {
+		num.Mul(num, pow10(e))
+	} else {
+		den.Mul(den, pow10(-e))
+	}
+
+	unscaled, exact := divRound(num, den, mode)
+
+	return Decimal{unscaled: unscaled, scale: scale}, exact, nil
+}
+
+// Round returns d with scale digits after the decimal point. Raising the
+// scale is always exact.
+func (d Decimal) Round(scale int, mode RoundingMode) (result Decimal, exact bool) {
+	if scale >= d.scale {
+		return Decimal{unscaled: new(big.Int).Mul(d.int(), pow10(scale-d.scale)), scale: scale}, true
+	}
+
+	unscaled, exact := divRound(d.int(), pow10(d.scale-scale), mode)
+
+	return Decimal{unscaled: unscaled, scale: scale}, exact
+}
+
+// Sqrt returns the square root of d with scale digits after the decimal
+// point. Scale 0 with Down gives the integer square root.
+func Sqrt(d Decimal, scale int, mode RoundingMode) (result Decimal, exact bool, err error) {
+	if d.Sign() < 0 {
+		return Decimal{}, false, ErrNegativeSquareRoot
+	}
+
+	// Take the root with at least one guard digit and enough digits that
+	// d × 10^(2×work) is an integer, then round the guard digits away.
+	work := scale + 1
+	if half := (d.scale + 1) / 2; half > work {
+		work = half
+	}
+	n := new(big.Int).Mul(d.int(), pow10(2*work-d.scale))
+	root := new(big.Int).Sqrt(n)
+	sticky := new(big.Int).Mul(root, root).Cmp(n) != 0
+
+	unit := pow10(work - scale)
+	q, dropped := new(big.Int).QuoRem(root, unit, new(big.Int))
+
+	half := new(big.Int).Lsh(dropped, 1).Cmp(unit)
+	if half == 0 && sticky {
+		half = 1
+	}
+	inexact := dropped.Sign() != 0 || sticky
+	if roundsAway(q, false, half, inexact, mode) {
+		q.Add(q, big.NewInt(1))
+	}
+
+	return Decimal{unscaled: q, scale: scale}, !inexact, nil
+}
+
+// divRound returns num / den rounded to an integer.
+func divRound(num *big.Int, den *big.Int, mode RoundingMode) (*big.Int, bool) {
+	neg := num.Sign()*den.Sign() < 0
+	absDen := new(big.Int).Abs(den)
+	q, r := new(big.Int).QuoRem(new(big.Int).Abs(num), absDen, new(big.Int))
+
+	half := new(big.Int).Lsh(r, 1).Cmp(absDen)
+	inexact := r.Sign() != 0
+	if roundsAway(q, neg, half, inexact, mode) {
+		q.Add(q, big.NewInt(1))
+	}
+	if neg {
+		q.Neg(q)
+	}
+
+	return q, !inexact
+}
+
+// roundsAway decides whether the truncated magnitude q must grow by one.
+// half compares the dropped part to one half of a unit.
+func roundsAway(q *big.Int, neg bool, half int, inexact bool, mode RoundingMode) bool {
+	if !inexact {
+		return false
+	}
+
+	switch mode {
+	case HalfUp:
+		return half >= 0
+	case HalfDown:
+		return half > 0
+	case Up:
+		return true
+	case Down:
+		return false
+	case Ceiling:
+		return !neg
+	case Floor:
+		return neg
+	default:
+		return half > 0 || (half == 0 && q.Bit(0) == 1)
+	}
+}
+
+// align brings a and b to the same scale without changing their values.
+func align(a Decimal, b Decimal) (Decimal, Decimal) {
+	switch {
+	case a.scale < b.scale:
+		a, _ = a.Round(b.scale, Down)
+	case b.scale < a.scale:
+		b, _ = b.Round(a.scale, Down)
+	}
+	return a, b
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func truncate(s string) string {
+	if len(s) > 32 {
+		return s[:32] + "..."
+	}
+	return s
+}
@@ -0,0 +1,178 @@
+package decimal
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, s string) Decimal {
+	t.Helper()
+
+	d, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	return d
+}
+
+func TestParseAndString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0", "0"},
+		{"42", "42"},
+		{"-0.125", "-0.125"},
+		{"+1.50", "1.50"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"1e3", "1000"},
+		{"1.5e-3", "0.0015"},
+		{"6.02E23", "602000000000000000000000"},
+		{"-0.0", "0.0"},
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+	}
+
+	for _, tt := range tests {
+		if got := mustParse(t, tt.in).String(); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, in := range []string{"", "-", ".", "1.2.3", "12a", "1e", "1e+", "0x10", "1e99999", " 1", strings.Repeat("9", MaxDigits+1)} {
+		if d, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %s, want an error", in, d)
+		}
+	}
+}
+
+func TestExactOperations(t *testing.T) {
+	big := "9223372036854775807" // math.MaxInt64
+
+	tests := []struct {
+		name string
+		op   func(Decimal, Decimal) Decimal
+		a, b string
+		want string
+	}{
+		{"add beyond int64", Add, big, "1", "9223372036854775808"},
+		{"add scales", Add, "0.1", "0.02", "0.12"},
+		{"add negative", Add, "-1.5", "0.25", "-1.25"},
+		{"sub", Sub, "1", "0.001", "0.999"},
+		{"sub below zero", Sub, "0.1", "0.3", "-0.2"},
+		{"mul", Mul, big, big, "85070591730234615847396907784232501249"},
+		{"mul scales", Mul, "1.5", "-0.02", "-0.030"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.op(mustParse(t, tt.a), mustParse(t, tt.b)).String(); got != tt.want {
+			t.Errorf("%s: %s, %s = %s, want %s", tt.name, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestQuoRoundingModes(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		scale int
+		mode  RoundingMode
+		want  string
+		exact bool
+	}{
+		{"1", "4", 2, HalfEven, "0.25", true},
+		{"1", "3", 5, HalfEven, "0.33333", false},
+		{"2", "3", 5, HalfEven, "0.66667", false},
+		{"2", "3", 5, Down, "0.66666", false},
+		{"-2", "3", 5, Down, "-0.66666", false},
+		{"-2", "3", 5, Floor, "-0.66667", false},
+		{"-2", "3", 5, Ceiling, "-0.66666", false},
+		{"2", "3", 5, Ceiling, "0.66667", false},
+		{"1", "3", 0, Up, "1", false},
+		{"-1", "3", 0, Up, "-1", false},
+		// ties
+		{"5", "2", 0, HalfEven, "2", false},
+		{"7", "2", 0, HalfEven, "4", false},
+		{"5", "2", 0, HalfUp, "3", false},
+		{"5", "2", 0, HalfDown, "2", false},
+		{"-5", "2", 0, HalfUp, "-3", false},
+		{"-5", "2", 0, HalfDown, "-2", false},
+		{"1.25", "0.5", 1, HalfEven, "2.5", true},
+		{"1", "0.001", 0, HalfEven, "1000", true},
+	}
+
+	for _, tt := range tests {
+		got, exact, err := Quo(mustParse(t, tt.a), mustParse(t, tt.b), tt.scale, tt.mode)
+		if err != nil {
+			t.Fatalf("%s / %s: %v", tt.a, tt.b, err)
+		}
+		if got.String() != tt.want || exact != tt.exact {
+			t.Errorf("%s / %s (scale %d, mode %d) = %s exact=%v, want %s exact=%v", tt.a, tt.b, tt.scale, tt.mode, got, exact, tt.want, tt.exact)
+		}
+	}
+
+	if _, _, err := Quo(mustParse(t, "1"), mustParse(t, "0.00"), 2, HalfEven); err != ErrDivisionByZero {
+		t.Errorf("division by zero returned %v", err)
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		in    string
+		scale int
+		mode  RoundingMode
+		want  string
+		exact bool
+	}{
+		{"1.005", 2, HalfEven, "1.00", false},
+		{"1.015", 2, HalfEven, "1.02", false},
+		{"1.005", 2, HalfUp, "1.01", false},
+		{"-1.005", 2, Floor, "-1.01", false},
+		{"1.5", 3, Down, "1.500", true},
+	}
+
+	for _, tt := range tests {
+		got, exact := mustParse(t, tt.in).Round(tt.scale, tt.mode)
+		if got.String() != tt.want || exact != tt.exact {
+			t.Errorf("Round(%s, %d) = %s exact=%v, want %s exact=%v", tt.in, tt.scale, got, exact, tt.want, tt.exact)
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	tests := []struct {
+		in    string
+		scale int
+		mode  RoundingMode
+		want  string
+		exact bool
+	}{
+		{"16", 0, HalfEven, "4", true},
+		{"2", 20, HalfEven, "1.41421356237309504880", false},
+		{"2", 20, Up, "1.41421356237309504881", false},
+		{"0.0225", 1, HalfEven, "0.2", false}, // 0.15 is a tie
+		{"0.0225", 1, HalfUp, "0.2", false},
+		{"0.0225", 1, HalfDown, "0.1", false},
+		{"0.0225", 2, HalfEven, "0.15", true},
+		{"0.001", 3, HalfEven, "0.032", false},
+		{"99", 0, Down, "9", false},
+		{"99", 0, HalfEven, "10", false},
+		{"1e100", 0, HalfEven, "1" + strings.Repeat("0", 50), true},
+		{"0", 3, HalfEven, "0.000", true},
+	}
+
+	for _, tt := range tests {
+		got, exact, err := Sqrt(mustParse(t, tt.in), tt.scale, tt.mode)
+		if err != nil {
+			t.Fatalf("Sqrt(%s): %v", tt.in, err)
+		}
+		if got.String() != tt.want || exact != tt.exact {
+			t.Errorf("Sqrt(%s, %d, mode %d) = %s exact=%v, want %s exact=%v", tt.in, tt.scale, tt.mode, got, exact, tt.want, tt.exact)
+		}
+	}
+
+	if _, _, err := Sqrt(mustParse(t, "-1"), 2, HalfEven); err != ErrNegativeSquareRoot {
+		t.Errorf("Sqrt(-1) returned %v", err)
+	}
+}
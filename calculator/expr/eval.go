@@ -0,0 +1,183 @@
+package expr
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+type node interface {
+	eval(vars map[string]float64) (float64, error)
+}
+
+type literal float64
+
+func (n literal) eval(map[string]float64) (float64, error) {
+	return float64(n), nil
+}
+
+type variable struct {
+	name   string
+	column int
+}
+
+func (n *variable) eval(vars map[string]float64) (float64, error) {
+	if v, ok := vars[n.name]; ok {
+		return v, nil
+	}
+	if v, ok := constants[n.name]; ok {
+		return v, nil
+	}
+	if _, ok := functions[n.name]; ok {
+		return 0, errorf(n.column, "%s is a function, call it as %s(...)", n.name, n.name)
+	}
+	return 0, errorf(n.column, "undefined variable %q", n.name)
+}
+
+type negation struct {
+	operand node
+}
+
+func (n *negation) eval(vars map[string]float64) (float64, error) {
+	v, err := n.operand.eval(vars)
+	return -v, err
+}
+
+type binary struct {
+	op          byte
+	column      int
+	left, right node
+}
+
+func (n *binary) eval(vars map[string]float64) (float64, error) {
+	l, err := n.left.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	r, err := n.right.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	var v float64
+	switch n.op {
+	case '+':
+		v = l + r
+	case '-':
+		v = l - r
+	case '*':
+		v = l * r
+	case '/':
+		if r == 0 {
+			return 0, errorf(n.column, "division by zero")
+		}
+		v = l / r
+	case '%':
+		if r == 0 {
+			return 0, errorf(n.column, "modulo by zero")
+		}
+		v = math.Mod(l, r)
+	case '^':
+		v = math.Pow(l, r)
+	}
+
+	return checked(v, n.column, formatFloat(l)+" "+string(n.op)+" "+formatFloat(r))
+}
+
+// function is a built-in function taking minArgs to maxArgs arguments, or
+// any number from minArgs on when maxArgs is negative.
+type function struct {
+	minArgs, maxArgs int
+	apply            func(args []float64) float64
+}
+
+func unary(f func(float64) float64) function {
+	return function{minArgs: 1, maxArgs: 1, apply: func(args []float64) float64 { return f(args[0]) }}
+}
+
+func pair(f func(float64, float64) float64) function {
+	return function{minArgs: 2, maxArgs: 2, apply: func(args []float64) float64 { return f(args[0], args[1]) }}
+}
+
+var functions = map[string]function{
+	"abs":   unary(math.Abs),
+	"sqrt":  unary(math.Sqrt),
+	"cbrt":  unary(math.Cbrt),
+	"exp":   unary(math.Exp),
+	"log10": unary(math.Log10),
+	"log2":  unary(math.Log2),
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"asin":  unary(math.Asin),
+	"acos":  unary(math.Acos),
+	"atan":  unary(math.Atan),
+	"sinh":  unary(math.Sinh),
+	"cosh":  unary(math.Cosh),
+	"tanh":  unary(math.Tanh),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"round": unary(math.Round),
+	"trunc": unary(math.Trunc),
+	"atan2": pair(math.Atan2),
+	"hypot": pair(math.Hypot),
+	"pow":   pair(math.Pow),
+	// log(x) is the natural logarithm, log(x, b) the logarithm in base b.
+	"log": {minArgs: 1, maxArgs: 2, apply: func(args []float64) float64 {
+		if len(args) == 2 {
+			return math.Log(args[0]) / math.Log(args[1])
+		}
+		return math.Log(args[0])
+	}},
+	"min": {minArgs: 1, maxArgs: -1, apply: func(args []float64) float64 {
+		m := args[0]
+		for _, v := range args[1:] {
+			m = math.Min(m, v)
+		}
+		return m
+	}},
+	"max": {minArgs: 1, maxArgs: -1, apply: func(args []float64) float64 {
+		m := args[0]
+		for _, v := range args[1:] {
+			m = math.Max(m, v)
+		}
+		return m
+	}},
+}
+
+type call struct {
+	name   string
+	column int
+	fn     function
+	args   []node
+}
+
+func (n *call) eval(vars map[string]float64) (float64, error) {
+	args := make([]float64, len(n.args))
+	formatted := make([]string, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		args[i], formatted[i] = v, formatFloat(v)
+	}
+
+	return checked(n.fn.apply(args), n.column, n.name+"("+strings.Join(formatted, ", ")+")")
+}
+
+// checked rejects results that are not finite real numbers, such as
+// sqrt(-1), log(0) or 10^400.
+func checked(v float64, column int, what string) (float64, error) {
+	switch {
+	case math.IsNaN(v):
+		return 0, errorf(column, "%s is not a real number", what)
+	case math.IsInf(v, 0):
+		return 0, errorf(column, "%s is not finite", what)
+	}
+	return v, nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
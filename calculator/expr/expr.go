@@ -0,0 +1,79 @@
+// Package expr parses and evaluates arithmetic expressions such as
+// "2 * (x + 1) ^ 2 - sqrt(y) % 3".
+//
+// Expressions are made of numbers, variables, the binary operators + - * / %
+// and ^ (power, right associative, binding tighter than unary minus so that
+// -2^2 is -4), unary + and -, parentheses and calls to the built-in functions.
+// The constants pi and e are predefined; variables passed to Eval shadow them.
+//
+// The built-in functions are abs, sqrt, cbrt, exp, log (natural, or log(x, b)
+// in base b), log10, log2, sin, cos, tan, asin, acos, atan, atan2, sinh, cosh,
+// tanh, floor, ceil, round, trunc, hypot, pow, and min and max of one or more
+// arguments.
+package expr
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxDepth bounds the nesting of parentheses, calls and unary operators, so
+// that hostile input cannot exhaust the stack.
+const MaxDepth = 256
+
+// Error is a syntax or evaluation error located at a column of the source.
+type Error struct {
+	// Column is the 1-based position, in runes, of the offending token.
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+func errorf(column int, format string, args ...interface{}) *Error {
+	return &Error{Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Expr is a parsed expression, safe for concurrent evaluation.
+type Expr struct {
+	root node
+}
+
+// Parse parses src. The returned error is always an *Error.
+func Parse(src string) (*Expr, error) {
+	p := &parser{lex: newLexer(src)}
+	p.next()
+
+	root, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != eof {
+		return nil, errorf(p.tok.column, "unexpected %s", p.tok)
+	}
+
+	return &Expr{root: root}, nil
+}
+
+// Eval computes the expression with the given variable bindings. It fails with
+// an *Error on unbound variables, domain errors such as division by zero or
+// the square root of a negative number, and results that are not finite.
+func (e *Expr) Eval(vars map[string]float64) (float64, error) {
+	return e.root.eval(vars)
+}
+
+// Evaluate parses and evaluates src in one go.
+func Evaluate(src string, vars map[string]float64) (float64, error) {
+	e, err := Parse(src)
+	if err != nil {
+		return 0, err
+	}
+	return e.Eval(vars)
+}
+
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
@@ -0,0 +1,143 @@
+package expr
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	vars := map[string]float64{"x": 3, "y": 16, "rate": 0.25, "pi": 3}
+
+	tests := []struct {
+		src  string
+		want float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2 ^ 2", -4},
+		{"(-2) ^ 2", 4},
+		{"2 ^ -1", 0.5},
+		{"--3", 3},
+		{"+4 - -4", 8},
+		{"7 % 4", 3},
+		{"-7 % 4", -3},
+		{"7.5 / 2.5", 3},
+		{".5 + 1.5e1", 15.5},
+		{"x * x + y", 25},
+		{"sqrt(y) + abs(-x)", 7},
+		{"min(4, x, 9) + max(1, 2)", 5},
+		{"max(5)", 5},
+		{"log(e)", 1},
+		{"log(8, 2)", 3},
+		{"log10(1000)", 3},
+		{"sin(0) + cos(0)", 1},
+		{"pi", 3},
+		{"floor(2.7) + ceil(2.1) + round(2.5)", 8},
+		{"hypot(3, 4) * rate", 1.25},
+		{"pow(2, 10)", 1024},
+		{"  1\t+\n1 ", 2},
+	}
+
+	for _, tt := range tests {
+		got, err := Evaluate(tt.src, vars)
+		if err != nil {
+			t.Errorf("Evaluate(%q): %v", tt.src, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Evaluate(%q) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestConstants(t *testing.T) {
+	got, err := Evaluate("2 * pi", nil)
+	if err != nil || got != 2*math.Pi {
+		t.Errorf("2 * pi = %v, %v", got, err)
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		src    string
+		column int
+		msg    string
+	}{
+		{"", 1, "unexpected end of expression"},
+		{"1 +", 4, "unexpected end of expression"},
+		{"1 + * 2", 5, `unexpected "*"`},
+		{"2 3", 3, "unexpected number 3"},
+		{"(1 + 2", 7, `expected ")" to close "(" at column 1`},
+		{"1 + 2)", 6, `unexpected ")"`},
+		{"1 $ 2", 3, "unexpected character '$'"},
+		{"1e+", 4, "missing exponent digits"},
+		{"1e999", 1, "out of range"},
+		{"foo(1)", 1, `unknown function "foo"`},
+		{"sqrt(1, 2)", 1, "sqrt takes 1 argument, got 2"},
+		{"min()", 1, "min takes at least 1 argument, got 0"},
+		{"max(1 2)", 7, `expected "," or ")" in call to max`},
+		{"sqrt(1,", 8, "unexpected end of expression"},
+		{"√2", 1, "unexpected character '√'"},
+		{"x + √2", 5, "unexpected character '√'"},
+		{strings.Repeat("(", MaxDepth+2) + "1" + strings.Repeat(")", MaxDepth+2), MaxDepth + 2, "nested more than"},
+		{strings.Repeat("-", MaxDepth+2) + "1", MaxDepth + 2, "nested more than"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.src)
+		var exprErr *Error
+		if !errors.As(err, &exprErr) {
+			t.Errorf("Parse(%q) returned %v, want an *Error", tt.src, err)
+			continue
+		}
+		if exprErr.Column != tt.column || !strings.Contains(exprErr.Msg, tt.msg) {
+			t.Errorf("Parse(%q) = %v, want column %d: ...%s...", tt.src, err, tt.column, tt.msg)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		src    string
+		column int
+		msg    string
+	}{
+		{"1 / (x - x)", 3, "division by zero"},
+		{"5 % 0", 3, "modulo by zero"},
+		{"2 + sqrt(-4)", 5, "sqrt(-4) is not a real number"},
+		{"log(0)", 1, "log(0) is not finite"},
+		{"10 ^ 400", 4, "10 ^ 400 is not finite"},
+		{"(-8) ^ 0.5", 6, "is not a real number"},
+		{"1 + z", 5, `undefined variable "z"`},
+		{"sqrt + 1", 1, "sqrt is a function"},
+	}
+
+	for _, tt := range tests {
+		_, err := Evaluate(tt.src, map[string]float64{"x": 1})
+		var exprErr *Error
+		if !errors.As(err, &exprErr) {
+			t.Errorf("Evaluate(%q) returned %v, want an *Error", tt.src, err)
+			continue
+		}
+		if exprErr.Column != tt.column || !strings.Contains(exprErr.Msg, tt.msg) {
+			t.Errorf("Evaluate(%q) = %v, want column %d: ...%s...", tt.src, err, tt.column, tt.msg)
+		}
+	}
+}
+
+func TestParsedExpressionIsReusable(t *testing.T) {
+	e, err := Parse("a * b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []float64{1, 2, 3} {
+		got, err := e.Eval(map[string]float64{"a": a, "b": 10})
+		if err != nil || got != a*10 {
+			t.Errorf("a=%v: got %v, %v", a, got, err)
+		}
+	}
+}
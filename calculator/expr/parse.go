@@ -0,0 +1,300 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	eof tokenKind = iota
+	number
+	ident
+	operator
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	value  float64
+	column int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case eof:
+		return "end of expression"
+	case number:
+		return "number " + t.text
+	case ident:
+		return "name " + strconv.Quote(t.text)
+	default:
+		return strconv.Quote(t.text)
+	}
+}
+
+type lexer struct {
+	src []rune
+	pos int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: []rune(src)}
+}
+
+// next returns the following token, or an error for characters that cannot
+// start one.
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && unicode.IsSpace(l.src[l.pos]) {
+		l.pos++
+	}
+	if l.pos == len(l.src) {
+		return token{kind: eof, column: l.pos + 1}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case isDigit(c) || (c == '.' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1])):
+		return l.number()
+	case unicode.IsLetter(c) || c == '_':
+		for l.pos < len(l.src) && (unicode.IsLetter(l.src[l.pos]) || isDigit(l.src[l.pos]) || l.src[l.pos] == '_') {
+			l.pos++
+		}
+		return token{kind: ident, text: string(l.src[start:l.pos]), column: start + 1}, nil
+	case c == '+' || c == '-' || c == '*' || c == '/' || c == '%' || c == '^' || c == '(' || c == ')' || c == ',':
+		l.pos++
+		return token{kind: operator, text: string(c), column: start + 1}, nil
+	default:
+		return token{}, errorf(start+1, "unexpected character %q", c)
+	}
+}
+
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	l.digits()
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		l.pos++
+		l.digits()
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if l.pos == len(l.src) || !isDigit(l.src[l.pos]) {
+			return token{}, errorf(l.pos+1, "missing exponent digits")
+		}
+		l.digits()
+	}
+
+	text := string(l.src[start:l.pos])
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return token{}, errorf(start+1, "number %s is out of range", text)
+	}
+
+	return token{kind: number, text: text, value: value, column: start + 1}, nil
+}
+
+func (l *lexer) digits() {
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+}
+
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parser is a recursive descent parser over the grammar
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/" | "%") unary }
+//	unary   = ("+" | "-") unary | power
+//	power   = primary [ "^" unary ]
+//	primary = number | name | name "(" [ expr { "," expr } ] ")" | "(" expr ")"
+type parser struct {
+	lex *lexer
+	tok token
+	err error
+}
+
+// next advances to the following token. A lexer error is kept and reported
+// by the parse function that looks at the token.
+func (p *parser) next() {
+	if p.err != nil {
+		return
+	}
+	p.tok, p.err = p.lex.next()
+}
+
+func (p *parser) is(op string) bool {
+	return p.err == nil && p.tok.kind == operator && p.tok.text == op
+}
+
+func (p *parser) parseExpr(depth int) (node, error) {
+	if depth > MaxDepth {
+		return nil, errorf(p.tok.column, "expression is nested more than %d levels deep", MaxDepth)
+	}
+
+	left, err := p.parseTerm(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.is("+") || p.is("-") {
+		op := p.tok
+		p.next()
+		right, err := p.parseTerm(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &binary{op: op.text[0], column: op.column, left: left, right: right}
+	}
+
+	return left, p.err
+}
+
+func (p *parser) parseTerm(depth int) (node, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.is("*") || p.is("/") || p.is("%") {
+		op := p.tok
+		p.next()
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &binary{op: op.text[0], column: op.column, left: left, right: right}
+	}
+
+	return left, p.err
+}
+
+func (p *parser) parseUnary(depth int) (node, error) {
+	if !p.is("+") && !p.is("-") {
+		return p.parsePower(depth)
+	}
+	if depth > MaxDepth {
+		return nil, errorf(p.tok.column, "expression is nested more than %d levels deep", MaxDepth)
+	}
+
+	op := p.tok
+	p.next()
+	operand, err := p.parseUnary(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	if op.text == "+" {
+		return operand, nil
+	}
+
+	return &negation{operand: operand}, nil
+}
+
+func (p *parser) parsePower(depth int) (node, error) {
+	base, err := p.parsePrimary(depth)
+	if err != nil {
+		return nil, err
+	}
+	if !p.is("^") {
+		return base, p.err
+	}
+
+	op := p.tok
+	p.next()
+	exponent, err := p.parseUnary(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &binary{op: '^', column: op.column, left: base, right: exponent}, nil
+}
+
+func (p *parser) parsePrimary(depth int) (node, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	tok := p.tok
+	switch {
+	case tok.kind == number:
+		p.next()
+		return literal(tok.value), nil
+	case tok.kind == ident:
+		p.next()
+		if !p.is("(") {
+			return &variable{name: tok.text, column: tok.column}, p.err
+		}
+		return p.parseCall(tok, depth)
+	case p.is("("):
+		p.next()
+		inner, err := p.parseExpr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if !p.is(")") {
+			return nil, errorf(p.tok.column, "expected \")\" to close \"(\" at column %d, found %s", tok.column, p.tok)
+		}
+		p.next()
+		return inner, nil
+	case tok.kind == eof:
+		return nil, errorf(tok.column, "unexpected end of expression, expected a number, name or \"(\"")
+	default:
+		return nil, errorf(tok.column, "unexpected %s, expected a number, name or \"(\"", tok)
+	}
+}
+
+// parseCall parses the argument list of a call to name, the current token
+// being the opening parenthesis.
+func (p *parser) parseCall(name token, depth int) (node, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, errorf(name.column, "unknown function %q", name.text)
+	}
+	p.next()
+
+	var args []node
+	for !p.is(")") {
+		if p.err != nil {
+			return nil, p.err
+		}
+		if len(args) > 0 {
+			if !p.is(",") {
+				return nil, errorf(p.tok.column, "expected \",\" or \")\" in call to %s, found %s", name.text, p.tok)
+			}
+			p.next()
+		}
+		arg, err := p.parseExpr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next()
+
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return nil, errorf(name.column, "%s takes %s, got %d", name.text, fn.arity(), len(args))
+	}
+
+	return &call{name: name.text, column: name.column, fn: fn, args: args}, p.err
+}
+
+func (f function) arity() string {
+	switch {
+	case f.maxArgs < 0 && f.minArgs == 1:
+		return "at least 1 argument"
+	case f.maxArgs < 0:
+		return fmt.Sprintf("at least %d arguments", f.minArgs)
+	case f.minArgs == f.maxArgs && f.minArgs == 1:
+		return "1 argument"
+	case f.minArgs == f.maxArgs:
+		return fmt.Sprintf("%d arguments", f.minArgs)
+	default:
+		return fmt.Sprintf("%d to %d arguments", f.minArgs, f.maxArgs)
+	}
+}
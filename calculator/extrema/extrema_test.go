@@ -0,0 +1,124 @@
+package extrema
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestWindowOverCount(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	start := time.Now()
+
+	for _, size := range []int{1, 2, 5, 64} {
+		w := NewWindow(size, 0)
+		var values []int64
+		for i := 0; i < 2000; i++ {
+			v := rng.Int63n(100) - 50
+			values = append(values, v)
+
+			want := v
+			for _, prev := range values[max(0, len(values)-size):] {
+				if prev > want {
+					want = prev
+				}
+			}
+			if got := w.Push(v, start); got != want {
+				t.Fatalf("size %d, push %d: maximum = %d, want %d", size, i, got, want)
+			}
+			if w.Len() > size {
+				t.Fatalf("size %d: the deque holds %d values", size, w.Len())
+			}
+		}
+	}
+}
+
+func TestWindowOverTime(t *testing.T) {
+	start := time.Now()
+	w := NewWindow(0, 10*time.Second)
+
+	steps := []struct {
+		value  int64
+		offset time.Duration
+		want   int64
+	}{
+		{5, 0, 5},
+		{3, 2 * time.Second, 5},
+		{-1, 9 * time.Second, 5},
+		{-4, 10 * time.Second, 3}, // 5 is 10s old and out
+		{-7, 12 * time.Second, -1},
+		{-9, 30 * time.Second, -9},
+	}
+	for _, step := range steps {
+		if got := w.Push(step.value, start.Add(step.offset)); got != step.want {
+			t.Errorf("push %d at %v: maximum = %d, want %d", step.value, step.offset, got, step.want)
+		}
+	}
+}
+
+func TestWindowOverCountAndTime(t *testing.T) {
+	start := time.Now()
+	w := NewWindow(2, time.Minute)
+
+	if got := w.Push(9, start); got != 9 {
+		t.Errorf("maximum = %d, want 9", got)
+	}
+	w.Push(1, start)
+	if got := w.Push(2, start); got != 2 {
+		t.Errorf("maximum = %d after 9 left the last two, want 2", got)
+	}
+	if got := w.Push(0, start.Add(time.Hour)); got != 0 {
+		t.Errorf("maximum = %d after the others got old, want 0", got)
+	}
+}
+
+func TestWindowReleasesMemory(t *testing.T) {
+	// decreasing values are all candidates; the slice must not keep the
+	// expired ones forever
+	w := NewWindow(100, 0)
+	now := time.Now()
+	for i := 0; i < 100000; i++ {
+		w.Push(int64(-i), now)
+	}
+	if w.Len() != 100 || cap(w.deque) > 1000 {
+		t.Errorf("deque holds %d values in a slice of capacity %d", w.Len(), cap(w.deque))
+	}
+}
+
+func TestTopK(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	top := NewTopK(3)
+
+	var values []int64
+	for i := 0; i < 1000; i++ {
+		v := rng.Int63n(1000) - 500
+		values = append(values, v)
+
+		sorted := append([]int64(nil), values...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+		want := sorted[:min(3, len(sorted))]
+
+		before := top.Values()
+		changed := top.Push(v)
+		got := top.Values()
+		if !equal(got, want) {
+			t.Fatalf("push %d: top 3 = %v, want %v", i, got, want)
+		}
+		if wantChanged := len(before) < 3 || v > before[2]; changed != wantChanged {
+			t.Fatalf("push %d of %d: Push reported changed=%v going from %v to %v", i, v, changed, before, got)
+		}
+	}
+}
+
+func equal(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
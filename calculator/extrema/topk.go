@@ -0,0 +1,54 @@
+package extrema
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// TopK keeps the k largest values pushed, in a min-heap whose root is the
+// smallest of them and the first to go.
+type TopK struct {
+	k    int
+	heap minHeap
+}
+
+// NewTopK returns an empty TopK for k > 0.
+func NewTopK(k int) *TopK {
+	return &TopK{k: k, heap: make(minHeap, 0, k)}
+}
+
+// Push adds v and reports whether it made it into the k largest values.
+func (t *TopK) Push(v int64) bool {
+	if len(t.heap) < t.k {
+		heap.Push(&t.heap, v)
+		return true
+	}
+	if v <= t.heap[0] {
+		return false
+	}
+	t.heap[0] = v
+	heap.Fix(&t.heap, 0)
+	return true
+}
+
+// Values returns the k largest values, or all of them while fewer were
+// pushed, in decreasing order.
+func (t *TopK) Values() []int64 {
+	values := append([]int64(nil), t.heap...)
+	sort.Slice(values, func(i, j int) bool { return values[i] > values[j] })
+	return values
+}
+
+type minHeap []int64
+
+func (h minHeap) Len() int            { return len(h) }
+func (h minHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(int64)) }
+
+func (h *minHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
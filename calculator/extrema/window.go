@@ -0,0 +1,64 @@
+// Package extrema tracks the largest values of a stream in bounded memory:
+// over a sliding window, with a monotonic deque, and the K largest overall,
+// with a heap.
+package extrema
+
+import (
+	"time"
+)
+
+type entry struct {
+	value int64
+	seq   int64
+	at    time.Time
+}
+
+// Window is the maximum of the values pushed last, over a count of values, a
+// span of time or both. It keeps the window's candidates for the maximum in a
+// deque of decreasing values: each push drops the smaller values before it,
+// which can never become the maximum again, so every value is added and
+// removed once and the deque never holds more than the window.
+type Window struct {
+	size     int64
+	duration time.Duration
+	deque    []entry
+	head     int
+	seq      int64
+}
+
+// NewWindow returns a window over the last size values and the values pushed
+// less than duration before the latest one. A zero size or duration leaves
+// that bound out; one of them must be set.
+func NewWindow(size int, duration time.Duration) *Window {
+	return &Window{size: int64(size), duration: duration}
+}
+
+// Push adds v, pushed at time at, and returns the maximum of the window.
+func (w *Window) Push(v int64, at time.Time) int64 {
+	w.seq++
+
+	for len(w.deque) > w.head && w.deque[len(w.deque)-1].value <= v {
+		w.deque = w.deque[:len(w.deque)-1]
+	}
+	w.deque = append(w.deque, entry{value: v, seq: w.seq, at: at})
+
+	for w.expired(w.deque[w.head], at) {
+		w.head++
+	}
+	// reclaim the space of expired entries once they make up half the slice
+	if w.head > 0 && w.head*2 >= len(w.deque) {
+		w.deque = append(w.deque[:0], w.deque[w.head:]...)
+		w.head = 0
+	}
+
+	return w.deque[w.head].value
+}
+
+// Len returns the number of values the deque holds.
+func (w *Window) Len() int {
+	return len(w.deque) - w.head
+}
+
+func (w *Window) expired(e entry, now time.Time) bool {
+	return (w.size > 0 && e.seq <= w.seq-w.size) || (w.duration > 0 && now.Sub(e.at) >= w.duration)
+}
@@ -0,0 +1,198 @@
+// Package factor decomposes integers of any size into prime factors.
+//
+// Small factors are found by trial division over a 2·3·5 wheel; what remains
+// is tested with Miller–Rabin and split with Brent's variant of Pollard's rho
+// until only primes are left. Numbers whose factors are all large, such as
+// products of two 40-digit primes, can still take a very long time: every
+// function takes a context, checks it between candidates and gives up once it
+// is done.
+package factor
+
+import (
+	"context"
+	"math/big"
+	"sort"
+)
+
+// TrialDivisionBound is the largest divisor tried by trial division. Factors
+// below it are reported as soon as they are found.
+const TrialDivisionBound = 1 << 16
+
+// wheel holds the gaps between the integers coprime to 30, starting from 7.
+var wheel = [8]int64{4, 2, 4, 2, 4, 6, 2, 6}
+
+var (
+	one = big.NewInt(1)
+	two = big.NewInt(2)
+)
+
+// Stage is the algorithm the engine is running.
+type Stage int
+
+const (
+	// TrialDivision divides by small candidates, see TrialDivisionBound.
+	TrialDivision Stage = iota
+	// Rho splits composites without small factors with Pollard's rho.
+	Rho
+)
+
+// Progress describes how far a factorization went.
+type Progress struct {
+	Stage Stage
+	// Current is the divisor being tried during trial division and the
+	// composite being split during Pollard's rho.
+	Current *big.Int
+	// Bound is the largest divisor trial division will try, which shrinks as
+	// factors are found. It is nil during Pollard's rho.
+	Bound *big.Int
+	// Tested counts the trial divisors and rho steps so far.
+	Tested int64
+}
+
+// Factorize calls found with every prime factor of n, repeated according to
+// its multiplicity and in ascending order. Nothing is reported for n < 2. It
+// returns the first error of found, or ctx.Err() when ctx is done first.
+func Factorize(ctx context.Context, n *big.Int, found func(*big.Int) error) error {
+	return FactorizeWithProgress(ctx, n, found, nil)
+}
+
+// FactorizeWithProgress is like Factorize and also calls progress, when not
+// nil, every time it checks ctx: before each trial divisor and after each
+// batch of rho steps. Calls are frequent, so progress should be cheap. Its
+// first error stops the factorization and is returned.
+func FactorizeWithProgress(ctx context.Context, n *big.Int, found func(*big.Int) error, progress func(Progress) error) error {
+	if n.Cmp(two) < 0 {
+		return nil
+	}
+
+	r := &run{ctx: ctx, found: found, progress: progress}
+	rest, err := r.trialDivide(new(big.Int).Set(n))
+	if err != nil || rest.Cmp(one) == 0 {
+		return err
+	}
+
+	// The factors of rest are all above the trial division bound; they come
+	// out of Pollard's rho in no particular order.
+	var large []*big.Int
+	if err := r.split(rest, &large); err != nil {
+		return err
+	}
+	sort.Slice(large, func(i, j int) bool { return large[i].Cmp(large[j]) < 0 })
+	for _, p := range large {
+		if err := found(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Factors returns the prime factors of n as Factorize reports them.
+func Factors(ctx context.Context, n *big.Int) ([]*big.Int, error) {
+	var factors []*big.Int
+	err := Factorize(ctx, n, func(p *big.Int) error {
+		factors = append(factors, p)
+		return nil
+	})
+	return factors, err
+}
+
+// run is the state of one factorization.
+type run struct {
+	ctx      context.Context
+	found    func(*big.Int) error
+	progress func(Progress) error
+	tested   int64
+}
+
+// check returns ctx.Err() or the error of the progress callback, which gets
+// what describe returns. describe is only called when there is a callback.
+func (r *run) check(describe func() Progress) error {
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+	if r.progress == nil {
+		return nil
+	}
+
+	p := describe()
+	p.Tested = r.tested
+	return r.progress(p)
+}
+
+// trialDivide divides n by 2, 3, 5 and the wheel candidates up to the bound,
+// reporting the factors it finds, and returns what is left of n.
+func (r *run) trialDivide(n *big.Int) (*big.Int, error) {
+	q, rem := new(big.Int), new(big.Int)
+	divisor := new(big.Int)
+	bound := trialBound(n)
+
+	try := func(d int64) error {
+		divisor.SetInt64(d)
+		err := r.check(func() Progress {
+			return Progress{Stage: TrialDivision, Current: big.NewInt(d), Bound: big.NewInt(bound)}
+		})
+		if err != nil {
+			return err
+		}
+		r.tested++
+		for {
+			q.QuoRem(n, divisor, rem)
+			if rem.Sign() != 0 {
+				return nil
+			}
+			n.Set(q)
+			bound = trialBound(n)
+			if err := r.found(big.NewInt(d)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, d := range []int64{2, 3, 5} {
+		if err := try(d); err != nil {
+			return nil, err
+		}
+	}
+	for d, i := int64(7), 0; d <= bound; d, i = d+wheel[i], (i+1)%len(wheel) {
+		if err := try(d); err != nil {
+			return nil, err
+		}
+	}
+
+	// Stopping early above the square root leaves 1 or a prime, which split
+	// recognizes without any rho iterations.
+	return n, nil
+}
+
+// trialBound is the largest divisor worth trying on n: its square root, but
+// no more than TrialDivisionBound.
+func trialBound(n *big.Int) int64 {
+	if n.BitLen() > 33 {
+		return TrialDivisionBound
+	}
+	if root := new(big.Int).Sqrt(n).Int64(); root < TrialDivisionBound {
+		return root
+	}
+	return TrialDivisionBound
+}
+
+// split appends the prime factors of n, which has no small factors, to primes.
+func (r *run) split(n *big.Int, primes *[]*big.Int) error {
+	if n.Cmp(one) == 0 {
+		return nil
+	}
+	if IsPrime(n) {
+		*primes = append(*primes, n)
+		return nil
+	}
+
+	d, err := r.rho(n)
+	if err != nil {
+		return err
+	}
+	if err := r.split(d, primes); err != nil {
+		return err
+	}
+	return r.split(new(big.Int).Quo(n, d), primes)
+}
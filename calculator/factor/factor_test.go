@@ -0,0 +1,214 @@
+package factor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func mustInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("invalid number %q", s)
+	}
+	return n
+}
+
+// naiveFactors is the reference the engine is checked against.
+func naiveFactors(n int64) []int64 {
+	var factors []int64
+	for d := int64(2); d*d <= n; d++ {
+		for n%d == 0 {
+			factors = append(factors, d)
+			n /= d
+		}
+	}
+	if n > 1 {
+		factors = append(factors, n)
+	}
+	return factors
+}
+
+func TestFactorsMatchTrialDivision(t *testing.T) {
+	for n := int64(-3); n < 5000; n++ {
+		got, err := Factors(context.Background(), big.NewInt(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := naiveFactors(n)
+		if len(got) != len(want) {
+			t.Fatalf("Factors(%d) = %v, want %v", n, got, want)
+		}
+		for i := range want {
+			if got[i].Int64() != want[i] {
+				t.Fatalf("Factors(%d) = %v, want %v", n, got, want)
+			}
+		}
+	}
+}
+
+func TestFactorsOfLargeNumbers(t *testing.T) {
+	tests := []struct {
+		n    string
+		want []string
+	}{
+		// a semiprime near 2^62, far beyond trial division
+		{"4611686039902224373", []string{"2147483647", "2147483659"}},
+		{"9223372036854775807", []string{"7", "7", "73", "127", "337", "92737", "649657"}},
+		{"18446744073709551617", []string{"274177", "67280421310721"}}, // 2^64 + 1
+		// 2^89 - 1, past the deterministic Miller–Rabin bound
+		{"618970019642690137449562111", []string{"618970019642690137449562111"}},
+		{"169707852197603080537070037433419722941", []string{"274177", "1000003", "618970019642690137449562111"}},
+		{"9999999967", []string{"9999999967"}},
+		{"4294967296", []string{"2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2"}},
+		{"18014397167303573", []string{"134217689", "134217757"}},
+		{"1000036000099", []string{"1000003", "1000033"}},
+		{"4295229443", []string{"65537", "65539"}},
+		{"4294967297", []string{"641", "6700417"}}, // 2^32 + 1
+	}
+
+	for _, tt := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		got, err := Factors(ctx, mustInt(t, tt.n))
+		cancel()
+		if err != nil {
+			t.Fatalf("Factors(%s): %v", tt.n, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("Factors(%s) = %v, want %v", tt.n, got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if got[i].String() != tt.want[i] {
+				t.Errorf("Factors(%s) = %v, want %v", tt.n, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestIsPrime(t *testing.T) {
+	for n := int64(-1); n < 20000; n++ {
+		want := len(naiveFactors(n)) == 1
+		if got := IsPrime(big.NewInt(n)); got != want {
+			t.Fatalf("IsPrime(%d) = %v, want %v", n, got, want)
+		}
+	}
+
+	tests := []struct {
+		n    string
+		want bool
+	}{
+		{"561", false},                                    // Carmichael number
+		{"3215031751", false},                             // strong pseudoprime to bases 2, 3, 5 and 7
+		{"3825123056546413051", false},                    // strong pseudoprime to bases up to 23
+		{"3317044064679887385961981", false},              // strong pseudoprime to bases up to 41
+		{"2305843009213693951", true},                     // 2^61 - 1
+		{"170141183460469231731687303715884105727", true}, // 2^127 - 1
+		{"170141183460469231731687303715884105729", false},
+	}
+	for _, tt := range tests {
+		if got := IsPrime(mustInt(t, tt.n)); got != tt.want {
+			t.Errorf("IsPrime(%s) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFactorizeReportsSmallFactorsFirstAndStopsWhenCanceled(t *testing.T) {
+	// 2 × (2^89 - 1) × (2^107 - 1): the 2 comes from trial division at once,
+	// splitting the rest would take Pollard's rho about 2^44 steps.
+	n := mustInt(t, "2")
+	n.Mul(n, mustInt(t, "618970019642690137449562111"))
+	n.Mul(n, mustInt(t, "162259276829213363391578010288127"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var found []*big.Int
+	done := make(chan error, 1)
+	go func() {
+		done <- Factorize(ctx, n, func(p *big.Int) error {
+			found = append(found, p)
+			cancel()
+			return nil
+		})
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Factorize returned %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Factorize did not return after its context was canceled")
+	}
+	if len(found) != 1 || found[0].Int64() != 2 {
+		t.Errorf("found %v before cancellation, want [2]", found)
+	}
+}
+
+func TestFactorizeStopsOnCallbackError(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+	err := Factorize(context.Background(), big.NewInt(1024), func(*big.Int) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Factorize returned %v after %d calls, want %v after 1", err, calls, stop)
+	}
+}
+
+func TestFactorizeWithProgress(t *testing.T) {
+	// 4611686039902224373 has no factor below the trial division bound, so
+	// trial division runs to the end before Pollard's rho takes over.
+	var stages []Stage
+	var last int64
+	err := FactorizeWithProgress(context.Background(), mustInt(t, "4611686039902224373"), func(*big.Int) error { return nil }, func(p Progress) error {
+		if p.Tested < last {
+			t.Fatalf("Tested went back from %d to %d", last, p.Tested)
+		}
+		last = p.Tested
+		if len(stages) == 0 || stages[len(stages)-1] != p.Stage {
+			stages = append(stages, p.Stage)
+		}
+		switch p.Stage {
+		case TrialDivision:
+			if p.Bound.Int64() != TrialDivisionBound || p.Current.Int64() > TrialDivisionBound {
+				t.Fatalf("trial division at %v with bound %v", p.Current, p.Bound)
+			}
+		case Rho:
+			if p.Bound != nil || p.Current.String() != "4611686039902224373" {
+				t.Fatalf("rho on %v with bound %v", p.Current, p.Bound)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stages) != 2 || stages[0] != TrialDivision || stages[1] != Rho {
+		t.Errorf("went through stages %v, want trial division then rho", stages)
+	}
+	if last < TrialDivisionBound/4 {
+		t.Errorf("tested %d candidates, want at least the trial divisors", last)
+	}
+
+	// the bound follows the square root of what is left
+	var bounds []int64
+	FactorizeWithProgress(context.Background(), big.NewInt(2*2*101*103), func(*big.Int) error { return nil }, func(p Progress) error {
+		bounds = append(bounds, p.Bound.Int64())
+		return nil
+	})
+	if len(bounds) == 0 || bounds[0] != 203 || bounds[len(bounds)-1] != 101 {
+		t.Errorf("bounds = %v, want from 203 down to 101", bounds)
+	}
+
+	stop := errors.New("stop")
+	err = FactorizeWithProgress(context.Background(), big.NewInt(97), func(*big.Int) error { return nil }, func(Progress) error { return stop })
+	if err != stop {
+		t.Errorf("FactorizeWithProgress returned %v, want the progress error", err)
+	}
+}
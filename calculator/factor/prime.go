@@ -0,0 +1,68 @@
+package factor
+
+import (
+	"math/big"
+)
+
+// witnesses are the Miller–Rabin bases used by IsPrime. Together they make
+// the test deterministic below deterministicBound.
+var witnesses = []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+// deterministicBound is 3317044064679887385961981, the smallest composite
+// that is a strong pseudoprime to all of the witnesses.
+var deterministicBound, _ = new(big.Int).SetString("3317044064679887385961981", 10)
+
+// IsPrime reports whether n is prime. The answer is exact below
+// 3317044064679887385961981 (more than 2^81); above it, numbers that pass
+// Miller–Rabin are also put through a Baillie–PSW test, for which no
+// counterexample is known.
+func IsPrime(n *big.Int) bool {
+	if n.Cmp(two) < 0 {
+		return false
+	}
+
+	r := new(big.Int)
+	for _, w := range witnesses {
+		p := big.NewInt(w)
+		if n.Cmp(p) == 0 {
+			return true
+		}
+		if r.Rem(n, p).Sign() == 0 {
+			return false
+		}
+	}
+
+	// n - 1 = d × 2^s with d odd
+	nMinus1 := new(big.Int).Sub(n, one)
+	s := nMinus1.TrailingZeroBits()
+	d := new(big.Int).Rsh(nMinus1, s)
+
+	for _, w := range witnesses {
+		if !strongProbablePrime(n, nMinus1, d, s, big.NewInt(w)) {
+			return false
+		}
+	}
+	if n.Cmp(deterministicBound) < 0 {
+		return true
+	}
+
+	return n.ProbablyPrime(0)
+}
+
+// strongProbablePrime runs one round of Miller–Rabin with base a.
+func strongProbablePrime(n, nMinus1, d *big.Int, s uint, a *big.Int) bool {
+	x := new(big.Int).Exp(a, d, n)
+	if x.Cmp(one) == 0 || x.Cmp(nMinus1) == 0 {
+		return true
+	}
+	for i := uint(1); i < s; i++ {
+		x.Mul(x, x).Mod(x, n)
+		if x.Cmp(nMinus1) == 0 {
+			return true
+		}
+		if x.Cmp(one) == 0 {
+			return false
+		}
+	}
+	return false
+}
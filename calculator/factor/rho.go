@@ -0,0 +1,81 @@
+package factor
+
+import (
+	"math/big"
+)
+
+// rhoBatch is the number of steps whose differences are multiplied together
+// before taking a gcd, which is what makes Brent's variant fast.
+const rhoBatch = 128
+
+// rho returns a non-trivial divisor of the composite n, which has no factors
+// below the trial division bound.
+func (r *run) rho(n *big.Int) (*big.Int, error) {
+	for c := int64(1); ; c++ {
+		d, err := r.brent(n, big.NewInt(c), big.NewInt(c+1))
+		if err != nil {
+			return nil, err
+		}
+		if d.Cmp(n) != 0 {
+			return d, nil
+		}
+		// The cycle closed without separating the factors; try another
+		// polynomial.
+	}
+}
+
+// brent runs Brent's cycle detection on x ↦ x² + c mod n starting from y0.
+// It returns a divisor of n greater than one, which may be n itself.
+func (r *run) brent(n, c, y0 *big.Int) (*big.Int, error) {
+	f := func(x *big.Int) {
+		x.Mul(x, x).Add(x, c).Mod(x, n)
+	}
+
+	y := new(big.Int).Set(y0)
+	x, ys := new(big.Int), new(big.Int)
+	q, g := big.NewInt(1), big.NewInt(1)
+	diff := new(big.Int)
+
+	describe := func() Progress {
+		return Progress{Stage: Rho, Current: new(big.Int).Set(n)}
+	}
+
+	for power := 1; g.Cmp(one) == 0; power *= 2 {
+		x.Set(y)
+		for i := 0; i < power; i++ {
+			if i%rhoBatch == rhoBatch-1 {
+				if err := r.check(describe); err != nil {
+					return nil, err
+				}
+			}
+			f(y)
+			r.tested++
+		}
+		for k := 0; k < power && g.Cmp(one) == 0; k += rhoBatch {
+			if err := r.check(describe); err != nil {
+				return nil, err
+			}
+			ys.Set(y)
+			for i := 0; i < rhoBatch && i < power-k; i++ {
+				f(y)
+				q.Mul(q, diff.Sub(x, y).Abs(diff)).Mod(q, n)
+				r.tested++
+			}
+			g.GCD(nil, nil, q, n)
+		}
+	}
+
+	if g.Cmp(n) == 0 {
+		// The batch overshot: the product became 0 mod n. Redo its steps one
+		// at a time to find the first gcd above one.
+		for {
+			f(ys)
+			g.GCD(nil, nil, diff.Sub(x, ys).Abs(diff), n)
+			if g.Cmp(one) != 0 {
+				break
+			}
+		}
+	}
+
+	return g, nil
+}
@@ -1,3 +1,3 @@
 #!/bin/bash
 
-protoc calculator/calculatorpb/calculator.proto --go_out=plugins=grpc:.
\ No newline at end of file
+protoc -I . -I third_party/googleapis calculator/calculatorpb/calculator.proto --go_out=plugins=grpc:. --grpc-gateway_out=logtostderr=true:.
\ No newline at end of file
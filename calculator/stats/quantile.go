@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"sort"
+)
+
+// DefaultExactLimit is the number of values a Distribution keeps by default.
+const DefaultExactLimit = 1 << 20
+
+// Distribution estimates quantiles of a stream. The zero Distribution is
+// empty and ready to use.
+type Distribution struct {
+	// ExactLimit is the number of values kept for exact quantiles. Past it
+	// they are folded into a t-digest and quantiles become estimates. Zero
+	// means DefaultExactLimit.
+	ExactLimit int
+
+	values []float64
+	sorted bool
+	digest *tdigest
+}
+
+// Add records x.
+func (d *Distribution) Add(x float64) {
+	if d.digest != nil {
+		d.digest.add(x)
+		return
+	}
+
+	d.values = append(d.values, x)
+	d.sorted = false
+
+	limit := d.ExactLimit
+	if limit <= 0 {
+		limit = DefaultExactLimit
+	}
+	if len(d.values) > limit {
+		d.digest = newTDigest(defaultCompression)
+		for _, v := range d.values {
+			d.digest.add(v)
+		}
+		d.values = nil
+	}
+}
+
+// Exact reports whether Quantile returns exact values.
+func (d *Distribution) Exact() bool {
+	return d.digest == nil
+}
+
+// Quantile returns the q-quantile for q in [0, 1], interpolating linearly
+// between the closest ranks, or 0 when empty.
+func (d *Distribution) Quantile(q float64) float64 {
+	if d.digest != nil {
+		return d.digest.quantile(q)
+	}
+	if len(d.values) == 0 {
+		return 0
+	}
+	if !d.sorted {
+		sort.Float64s(d.values)
+		d.sorted = true
+	}
+
+	rank := q * float64(len(d.values)-1)
+	lower := int(rank)
+	if lower >= len(d.values)-1 {
+		return d.values[len(d.values)-1]
+	}
+	return d.values[lower] + (rank-float64(lower))*(d.values[lower+1]-d.values[lower])
+}
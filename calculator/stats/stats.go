@@ -0,0 +1,95 @@
+// Package stats summarizes streams of numbers in a single pass: count, sum,
+// mean, variance, extremes and quantiles.
+//
+// Summary keeps constant state whatever the length of the stream; the mean
+// and variance follow Welford's algorithm, so they neither overflow nor lose
+// precision the way a running sum of squares does. Distribution keeps the
+// values themselves for exact quantiles up to a limit, and a t-digest beyond.
+package stats
+
+import (
+	"math"
+)
+
+// Summary accumulates the moments and extremes of a stream. The zero Summary
+// is empty and ready to use.
+type Summary struct {
+	count    int64
+	mean, m2 float64
+	// sum and compensation form a Neumaier sum.
+	sum, compensation float64
+	min, max          float64
+}
+
+// Add records x.
+func (s *Summary) Add(x float64) {
+	s.count++
+	if s.count == 1 {
+		s.min, s.max = x, x
+	}
+	s.min, s.max = math.Min(s.min, x), math.Max(s.max, x)
+
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (x - s.mean)
+
+	t := s.sum + x
+	if math.Abs(s.sum) >= math.Abs(x) {
+		s.compensation += (s.sum - t) + x
+	} else {
+		s.compensation += (x - t) + s.sum
+	}
+	s.sum = t
+}
+
+// Count returns the number of values added.
+func (s *Summary) Count() int64 {
+	return s.count
+}
+
+// Sum returns the sum of the values, compensated for rounding errors.
+func (s *Summary) Sum() float64 {
+	return s.sum + s.compensation
+}
+
+// Mean returns the arithmetic mean, 0 when empty.
+func (s *Summary) Mean() float64 {
+	return s.mean
+}
+
+// Variance returns the population variance, 0 when empty.
+func (s *Summary) Variance() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	return s.m2 / float64(s.count)
+}
+
+// SampleVariance returns the unbiased sample variance, 0 for less than two
+// values.
+func (s *Summary) SampleVariance() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.count-1)
+}
+
+// StdDev returns the population standard deviation.
+func (s *Summary) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// SampleStdDev returns the sample standard deviation.
+func (s *Summary) SampleStdDev() float64 {
+	return math.Sqrt(s.SampleVariance())
+}
+
+// Min returns the smallest value, 0 when empty.
+func (s *Summary) Min() float64 {
+	return s.min
+}
+
+// Max returns the largest value, 0 when empty.
+func (s *Summary) Max() float64 {
+	return s.max
+}
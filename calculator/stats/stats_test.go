@@ -0,0 +1,133 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func near(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance
+}
+
+func TestSummary(t *testing.T) {
+	var s Summary
+	if s.Count() != 0 || s.Mean() != 0 || s.Variance() != 0 || s.SampleVariance() != 0 {
+		t.Errorf("empty summary is not zero: %+v", s)
+	}
+
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		s.Add(x)
+	}
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"count", float64(s.Count()), 8},
+		{"sum", s.Sum(), 40},
+		{"mean", s.Mean(), 5},
+		{"variance", s.Variance(), 4},
+		{"stddev", s.StdDev(), 2},
+		{"sample variance", s.SampleVariance(), 32.0 / 7},
+		{"sample stddev", s.SampleStdDev(), math.Sqrt(32.0 / 7)},
+		{"min", s.Min(), 2},
+		{"max", s.Max(), 9},
+	}
+	for _, c := range checks {
+		if !near(c.got, c.want, 1e-12) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestSummaryIsNumericallyStable(t *testing.T) {
+	// A naive sum of squares cancels out catastrophically on values with a
+	// large offset and a small spread.
+	var s Summary
+	for _, x := range []float64{4, 7, 13, 16} {
+		s.Add(1e9 + x)
+	}
+	if !near(s.Mean(), 1e9+10, 1e-6) || !near(s.SampleVariance(), 30, 1e-6) {
+		t.Errorf("mean = %v, sample variance = %v, want %v and 30", s.Mean(), s.SampleVariance(), 1e9+10)
+	}
+
+	// int64 extremes neither overflow nor cancel out
+	var big Summary
+	big.Add(math.MaxInt64)
+	big.Add(math.MaxInt64)
+	big.Add(math.MinInt64)
+	if !near(big.Sum(), math.MaxInt64, 1e4) || !near(big.Mean(), math.MaxInt64/3.0, 1e4) {
+		t.Errorf("sum = %v, mean = %v", big.Sum(), big.Mean())
+	}
+
+	// the compensated sum recovers what plain float addition drops
+	var sum Summary
+	for _, x := range []float64{1, 1e100, 1, -1e100} {
+		sum.Add(x)
+	}
+	if sum.Sum() != 2 {
+		t.Errorf("sum = %v, want 2", sum.Sum())
+	}
+}
+
+func TestExactQuantiles(t *testing.T) {
+	var d Distribution
+	if d.Quantile(0.5) != 0 {
+		t.Errorf("median of nothing = %v", d.Quantile(0.5))
+	}
+
+	for _, x := range []float64{15, 20, 35, 40, 50} {
+		d.Add(x)
+	}
+	tests := []struct {
+		q, want float64
+	}{
+		{0, 15}, {0.25, 20}, {0.4, 29}, {0.5, 35}, {0.9, 46}, {1, 50},
+	}
+	for _, tt := range tests {
+		if got := d.Quantile(tt.q); !near(got, tt.want, 1e-12) {
+			t.Errorf("Quantile(%v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+	if !d.Exact() {
+		t.Error("a handful of values should give exact quantiles")
+	}
+
+	d.Add(0)
+	if got := d.Quantile(0.5); got != 27.5 {
+		t.Errorf("median after adding a value = %v, want 27.5", got)
+	}
+}
+
+func TestApproximateQuantiles(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	d := Distribution{ExactLimit: 1000}
+	values := make([]float64, 200000)
+	for i := range values {
+		values[i] = rng.NormFloat64()*10 + 100
+		d.Add(values[i])
+	}
+	if d.Exact() {
+		t.Fatal("quantiles should be estimates past the exact limit")
+	}
+
+	sort.Float64s(values)
+	for _, q := range []float64{0, 0.001, 0.01, 0.25, 0.5, 0.75, 0.99, 0.999, 1} {
+		got := d.Quantile(q)
+
+		// compare ranks rather than values: the estimate must sit within
+		// 0.5% of the stream from the true quantile, less in the tails
+		rank := float64(sort.SearchFloat64s(values, got)) / float64(len(values))
+		tolerance := 0.005
+		if q < 0.01 || q > 0.99 {
+			tolerance = 0.001
+		}
+		if !near(rank, q, tolerance) {
+			t.Errorf("Quantile(%v) = %v, which is the %v quantile", q, got, rank)
+		}
+	}
+	if d.Quantile(0) != values[0] || d.Quantile(1) != values[len(values)-1] {
+		t.Errorf("extremes = %v, %v, want %v, %v", d.Quantile(0), d.Quantile(1), values[0], values[len(values)-1])
+	}
+}
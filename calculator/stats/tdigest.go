@@ -0,0 +1,113 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// defaultCompression bounds a t-digest to a few hundred centroids, for
+// quantile errors well below 1% of the rank.
+const defaultCompression = 200
+
+type centroid struct {
+	mean, weight float64
+}
+
+// tdigest is Dunning's merging t-digest: values are buffered and
+// periodically merged into centroids whose size is bounded by the k1 scale
+// function, small near the tails and large around the median.
+type tdigest struct {
+	compression float64
+	centroids   []centroid
+	weight      float64
+	buffer      []centroid
+	min, max    float64
+}
+
+func newTDigest(compression float64) *tdigest {
+	return &tdigest{compression: compression, min: math.Inf(1), max: math.Inf(-1)}
+}
+
+func (t *tdigest) add(x float64) {
+	t.min, t.max = math.Min(t.min, x), math.Max(t.max, x)
+	t.buffer = append(t.buffer, centroid{mean: x, weight: 1})
+	if len(t.buffer) >= 10*int(t.compression) {
+		t.merge()
+	}
+}
+
+// k is the k1 scale function, mapping a quantile to the index of the
+// centroid it falls in; centroids may span at most one unit of k.
+func (t *tdigest) k(q float64) float64 {
+	return t.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// q is the inverse of k.
+func (t *tdigest) q(k float64) float64 {
+	if k >= t.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/t.compression) + 1) / 2
+}
+
+func (t *tdigest) merge() {
+	if len(t.buffer) == 0 {
+		return
+	}
+
+	all := append(t.buffer, t.centroids...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+	t.weight += float64(len(t.buffer))
+	t.buffer = t.buffer[:0]
+
+	merged := []centroid{all[0]}
+	before := 0.0 // weight of the centroids left of the last merged one
+	limit := t.weight * t.q(t.k(0)+1)
+	for _, c := range all[1:] {
+		last := &merged[len(merged)-1]
+		if before+last.weight+c.weight <= limit {
+			last.weight += c.weight
+			last.mean += (c.mean - last.mean) * c.weight / last.weight
+			continue
+		}
+		before += last.weight
+		limit = t.weight * t.q(t.k(before/t.weight)+1)
+		merged = append(merged, c)
+	}
+	t.centroids = merged
+}
+
+func (t *tdigest) quantile(q float64) float64 {
+	t.merge()
+	if len(t.centroids) == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return t.min
+	}
+	if q >= 1 {
+		return t.max
+	}
+
+	// Each centroid stands for its weight spread around its mean; interpolate
+	// between the centers, and between the extremes and the outer centers.
+	target := q * t.weight
+	first, last := t.centroids[0], t.centroids[len(t.centroids)-1]
+	if target < first.weight/2 {
+		return t.min + (first.mean-t.min)*target/(first.weight/2)
+	}
+	if target > t.weight-last.weight/2 {
+		return last.mean + (t.max-last.mean)*(target-(t.weight-last.weight/2))/(last.weight/2)
+	}
+
+	cumulative := 0.0
+	for i := 0; i < len(t.centroids)-1; i++ {
+		c, next := t.centroids[i], t.centroids[i+1]
+		center, nextCenter := cumulative+c.weight/2, cumulative+c.weight+next.weight/2
+		if target <= nextCenter {
+			return c.mean + (next.mean-c.mean)*(target-center)/(nextCenter-center)
+		}
+		cumulative += c.weight
+	}
+	return last.mean
+}
@@ -0,0 +1,147 @@
+// Command gateway serves GreetService and CalculatorService as REST/JSON for
+// callers that cannot speak gRPC. The routes come from the google.api.http
+// options in the protos, e.g.
+//
+//	curl -X POST localhost:8080/v1/sum -d '{"first_number": 3, "second_number": 10}'
+//	curl localhost:8080/v1/sqrt/16
+//	curl localhost:8080/v1/primes/120
+//
+// Server-streaming RPCs answer with newline-delimited JSON, one
+// {"result": ...} object per message and a final {"error": ...} object if the
+// stream fails. gRPC status codes become HTTP status codes, InvalidArgument
+// for example becomes 400. The Authorization header of the caller is passed on
+// to the servers, and is the only credential they see: the gateway has no
+// identity of its own, so servers that authenticate answer calls without the
+// header with 401.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/logging"
+	"grpc-go-course/internal/tlsconfig"
+	"grpc-go-course/internal/tracing"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:], config.Options{
+		Role:      config.Gateway,
+		EnvPrefix: "GATEWAY",
+		Defaults:  config.GatewayDefaults("0.0.0.0:8080"),
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	logger, err := logging.New(os.Stderr, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	slog.SetDefault(logger)
+
+	tracer, err := tracing.Setup(context.Background(), cfg.Tracing, "gateway")
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	defer tracer.Shutdown(context.Background())
+
+	transport, err := tlsconfig.DialOption(cfg.TLS)
+	if err != nil {
+		log.Fatalf("failed to set up TLS: %v", err)
+	}
+
+	opts := append(cfg.DialOptions(), transport)
+	opts = append(opts, tracer.DialOptions()...)
+
+	greetConn, err := grpc.Dial(cfg.Upstream.GreetTarget, opts...)
+	if err != nil {
+		log.Fatalf("could not connect to greet: %v", err)
+	}
+	defer greetConn.Close()
+
+	calculatorConn, err := grpc.Dial(cfg.Upstream.CalculatorTarget, opts...)
+	if err != nil {
+		log.Fatalf("could not connect to calculator: %v", err)
+	}
+	defer calculatorConn.Close()
+
+	handler, err := newHandler(context.Background(), greetConn, calculatorConn)
+	if err != nil {
+		log.Fatalf("failed to register handlers: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", cfg.ListenAddr)
+	if err != nil {
+		log.Fatalf("failed to listen %v", err)
+	}
+
+	if err := serve(&http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}, lis, cfg.DrainTimeout); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
+
+// newHandler routes the REST API of both services to their connections.
+func newHandler(ctx context.Context, greetConn *grpc.ClientConn, calculatorConn *grpc.ClientConn) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			// Zero results such as {"result": 0} must not disappear from the response.
+			MarshalOptions: protojson.MarshalOptions{EmitUnpopulated: true},
+		}),
+	)
+
+	if err := greetpb.RegisterGreetServiceHandler(ctx, mux, greetConn); err != nil {
+		return nil, err
+	}
+	if err := calculatorpb.RegisterCalculatorServiceHandler(ctx, mux, calculatorConn); err != nil {
+		return nil, err
+	}
+
+	return mux, nil
+}
+
+// serve runs srv until SIGINT or SIGTERM and then gives open requests
+// drainTimeout to finish.
+func serve(srv *http.Server, lis net.Listener, drainTimeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(lis)
+	}()
+	slog.Info("gateway listening", slog.String("addr", lis.Addr().String()))
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down, draining open requests", slog.Duration("deadline", drainTimeout))
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("drain deadline passed, closing open requests", slog.Any("error", err))
+		srv.Close()
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
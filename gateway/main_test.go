@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"google.golang.org/grpc"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/calculator/calculatorservice"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/greet/greetservice"
+	"grpc-go-course/internal/auth"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/grpctest"
+	"grpc-go-course/internal/validate"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func startGateway(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	// the servers validate requests like the real binaries do
+	greetServer := grpc.NewServer(validate.NewInterceptor(validate.Options{}).ServerOptions()...)
+	greetpb.RegisterGreetServiceServer(greetServer, &greetservice.Server{})
+	calculatorServer := grpc.NewServer(validate.NewInterceptor(validate.Options{}).ServerOptions()...)
+	calculatorpb.RegisterCalculatorServiceServer(calculatorServer, calculatorservice.New(config.Batch{}))
+	greetConn, calculatorConn := grpctest.Dial(t, greetServer), grpctest.Dial(t, calculatorServer)
+	handler, err := newHandler(context.Background(), greetConn, calculatorConn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestUnaryRoutes(t *testing.T) {
+	srv := startGateway(t)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantBody map[string]interface{}
+	}{
+		{"sum", http.MethodPost, "/v1/sum", `{"first_number": 3, "second_number": 10}`, http.StatusOK, map[string]interface{}{"result": "13"}},
+		{"sum of nothing", http.MethodPost, "/v1/sum", `{}`, http.StatusOK, map[string]interface{}{"result": "0"}},
+		{"sqrt", http.MethodGet, "/v1/sqrt/16", "", http.StatusOK, map[string]interface{}{"numberRoot": 4.0}},
+		{"sum overflow", http.MethodPost, "/v1/sum", `{"first_number": "9223372036854775807", "second_number": 1}`, http.StatusBadRequest, map[string]interface{}{"code": 11.0}},
+		{"negative sqrt", http.MethodGet, "/v1/sqrt/-4", "", http.StatusBadRequest, map[string]interface{}{"code": 3.0}},
+		{"sqrt of a word", http.MethodGet, "/v1/sqrt/four", "", http.StatusBadRequest, nil},
+		{"greet", http.MethodPost, "/v1/greet", `{"greeting": {"first_name": "Ada", "last_name": "Lovelace"}}`, http.StatusOK, map[string]interface{}{"result": "Ada Lovelace"}},
+		{"greet nobody", http.MethodPost, "/v1/greet", `{}`, http.StatusBadRequest, map[string]interface{}{"code": 3.0}},
+		{"unknown route", http.MethodGet, "/v1/nothing", "", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.wantCode {
+				t.Fatalf("status = %d, want %d", res.StatusCode, tt.wantCode)
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatalf("response is not JSON: %v", err)
+			}
+			for key, want := range tt.wantBody {
+				if body[key] != want {
+					t.Errorf("%s = %v (%T), want %v", key, body[key], body[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestServerStreamingIsNewlineDelimitedJSON(t *testing.T) {
+	srv := startGateway(t)
+
+	res, err := http.Get(srv.URL + "/v1/primes/120")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", res.StatusCode)
+	}
+
+	var factors []string
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		var line struct {
+			Result struct {
+				PrimeFactor string `json:"primeFactor"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		factors = append(factors, line.Result.PrimeFactor)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := strings.Join(factors, ","), "2,2,2,3,5"; got != want {
+		t.Errorf("factors = %s, want %s", got, want)
+	}
+}
+
+func TestGatewayForwardsOnlyTheCallersCredentials(t *testing.T) {
+	authenticator, err := auth.New(config.Auth{Enabled: true, APIKeys: []config.APIKey{
+		{Key: "caller-key", Subject: "caller"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	subjects := make(chan string, 1)
+	s := grpc.NewServer(append(authenticator.ServerOptions(), grpc.ChainUnaryInterceptor(
+		func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			id, _ := auth.FromContext(ctx)
+			subjects <- id.Subject
+			return handler(ctx, req)
+		}))...)
+	greetpb.RegisterGreetServiceServer(s, &greetservice.Server{})
+	handler, err := newHandler(context.Background(), grpctest.Dial(t, s), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		authorization string
+		status        int
+		// subject is who the server saw, empty when it rejected the call
+		subject string
+	}{
+		{"ApiKey caller-key", http.StatusOK, "caller"},
+		{"", http.StatusUnauthorized, ""},
+		{"ApiKey stolen-key", http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/v1/greet", strings.NewReader(`{"greeting": {"first_name": "Ada"}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.authorization != "" {
+			req.Header.Set("Authorization", tt.authorization)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != tt.status {
+			t.Errorf("Authorization %q: status = %d, want %d", tt.authorization, res.StatusCode, tt.status)
+		}
+		subject := ""
+		select {
+		case subject = <-subjects:
+		default:
+		}
+		if subject != tt.subject {
+			t.Errorf("Authorization %q reached the handler as %q, want %q", tt.authorization, subject, tt.subject)
+		}
+	}
+}
@@ -2,20 +2,56 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"github.com/simplesteph/grpc-go-course/greet/greetpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/internal/auth"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/healthcheck"
+	"grpc-go-course/internal/tlsconfig"
+	"grpc-go-course/internal/tracing"
 	"io"
 	"log"
+	"os"
 	"time"
 )
 
 func main() {
+	healthCheck := flag.Bool("healthcheck", false, "only check that GreetService is SERVING and exit non-zero otherwise")
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:], config.Options{
+		Role:      config.Client,
+		EnvPrefix: "GREET",
+		Defaults:  config.ClientDefaults("localhost:50051"),
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	fmt.Printf("hello from client\n")
 
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	transport, err := tlsconfig.DialOption(cfg.TLS)
+	if err != nil {
+		log.Fatalf("failed to set up TLS: %v", err)
+	}
+
+	authOpts, err := auth.DialOptions(cfg.Auth)
+	if err != nil {
+		log.Fatalf("failed to set up authentication: %v", err)
+	}
+
+	tracer, err := tracing.Setup(context.Background(), cfg.Tracing, "greet_client")
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	defer tracer.Shutdown(context.Background())
+
+	opts := append(cfg.DialOptions(), transport)
+	opts = append(opts, tracer.DialOptions()...)
+	conn, err := grpc.Dial(cfg.Target, append(opts, authOpts...)...)
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
 	}
@@ -27,6 +63,11 @@ func main() {
 		}
 	}(conn)
 
+	if *healthCheck {
+		runHealthCheck(conn)
+		return
+	}
+
 	c := greetpb.NewGreetServiceClient(conn)
 	//fmt.Printf("created client: %f", c)
 
@@ -36,6 +77,16 @@ func main() {
 	makeUnaryWithDeadline(c, 1000*time.Millisecond)
 }
 
+func runHealthCheck(conn *grpc.ClientConn) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if code := healthcheck.Run(ctx, conn, "greet.GreetService", os.Stdout, os.Stderr); code != 0 {
+		conn.Close()
+		os.Exit(code)
+	}
+}
+
 func makeUnaryCall(c greetpb.GreetServiceClient) {
 	fmt.Printf("Starting to do a unary rpc\n")
 
@@ -70,6 +121,8 @@ func makeServerStreamingCall(c greetpb.GreetServiceClient) {
 			FirstName: "John",
 			LastName:  "Johnson",
 		},
+		Times:    5,
+		Interval: durationpb.New(500 * time.Millisecond),
 	}
 
 	// make a stream request
@@ -148,19 +201,22 @@ func makeBidirectionalStreamingCall(c greetpb.GreetServiceClient) {
 	}
 
 	waitChannel := make(chan struct{})
-	// send a bunch of messages to the client (go routine)
+	// send a bunch of messages to the server (go routine)
 	go func() {
 		// function to send a bunch of messages
 		for _, req := range requests {
 			fmt.Printf("Sending message: %v\n", req)
-			stream.Send(req)
+			if err := stream.Send(req); err != nil {
+				log.Printf("error while sending: %v", err)
+				break
+			}
 			time.Sleep(1000 * time.Millisecond)
 		}
 		// close stream after sending messages
 		stream.CloseSend()
 	}()
 
-	// receive a bunch of messages from the server (go routine)
+	// receive a response per message from the server (go routine)
 	go func() {
 		// function to receive a bunch of messages
 		for {
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/greet/greetservice"
+	"grpc-go-course/internal/grpcweb"
+	"grpc-go-course/internal/validate"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+const dashboardOrigin = "https://dashboard.example"
+
+// startGRPCWeb serves GreetService through the gRPC-Web listener and returns its base URL.
+func startGRPCWeb(t *testing.T) string {
+	t.Helper()
+
+	s := grpc.NewServer(validate.NewInterceptor(validate.Options{}).ServerOptions()...)
+	greetpb.RegisterGreetServiceServer(s, &greetservice.Server{})
+	t.Cleanup(s.Stop)
+
+	web, lis, err := grpcweb.Listen("127.0.0.1:0", grpcweb.NewHandler(s, []string{dashboardOrigin}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go web.Serve(lis)
+	t.Cleanup(func() { web.Close() })
+
+	return "http://" + web.Addr
+}
+
+// protoRecorder remembers the protocol of every response.
+type protoRecorder struct {
+	http.RoundTripper
+
+	mu     sync.Mutex
+	protos []string
+}
+
+func (r *protoRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := r.RoundTripper.RoundTrip(req)
+	if err == nil {
+		r.mu.Lock()
+		r.protos = append(r.protos, res.Proto)
+		r.mu.Unlock()
+	}
+	return res, err
+}
+
+func TestGreetManyTimesOverGRPCWeb(t *testing.T) {
+	baseURL := startGRPCWeb(t)
+
+	transports := map[string]http.RoundTripper{
+		"HTTP/1.1": &http.Transport{},
+		"HTTP/2.0": &http2.Transport{
+			// h2c: HTTP/2 without TLS, the way the listener speaks it in cleartext.
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			},
+		},
+	}
+
+	for wantProto, transport := range transports {
+		t.Run(wantProto, func(t *testing.T) {
+			recorder := &protoRecorder{RoundTripper: transport}
+			client := &grpcweb.Client{
+				HTTPClient: &http.Client{Transport: recorder},
+				BaseURL:    baseURL,
+				Header:     http.Header{"Origin": {dashboardOrigin}},
+			}
+
+			stream, err := client.NewStream(context.Background(), "/greet.GreetService/GreetManyTimes", &greetpb.GreetManyTimesRequest{
+				Greeting: &greetpb.Greeting{FirstName: "Ada"},
+				Times:    3,
+				Interval: durationpb.New(10 * time.Millisecond),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stream.Close()
+
+			var results []string
+			for {
+				var res greetpb.GreetManyTimesResponse
+				err := stream.Recv(&res)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("stream failed after %d messages: %v", len(results), err)
+				}
+				results = append(results, res.GetResult())
+			}
+
+			if len(results) != 3 {
+				t.Errorf("received %d messages (%v), want 3", len(results), results)
+			}
+			if got := stream.Header.Get("Access-Control-Allow-Origin"); got != dashboardOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, dashboardOrigin)
+			}
+			if len(recorder.protos) != 1 || recorder.protos[0] != wantProto {
+				t.Errorf("responses came over %v, want %s", recorder.protos, wantProto)
+			}
+		})
+	}
+}
+
+func TestGRPCWebReportsStatusErrors(t *testing.T) {
+	client := &grpcweb.Client{BaseURL: startGRPCWeb(t)}
+
+	var res greetpb.GreetResponse
+	err := client.Invoke(context.Background(), "/greet.GreetService/Greet", &greetpb.GreetRequest{
+		Greeting: &greetpb.Greeting{FirstName: "Ada", LastName: "Lovelace"},
+	}, &res)
+	if err != nil || res.GetResult() != "Ada Lovelace" {
+		t.Fatalf("Greet = %q, %v", res.GetResult(), err)
+	}
+
+	stream, err := client.NewStream(context.Background(), "/greet.GreetService/GreetManyTimes", &greetpb.GreetManyTimesRequest{Times: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	if err := stream.Recv(&greetpb.GreetManyTimesResponse{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("GreetManyTimes with times -1 returned %v, want InvalidArgument", err)
+	}
+}
+
+func TestGRPCWebPreflight(t *testing.T) {
+	baseURL := startGRPCWeb(t)
+
+	tests := []struct {
+		origin string
+		allow  string
+	}{
+		{dashboardOrigin, dashboardOrigin},
+		{"https://elsewhere.example", ""},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodOptions, baseURL+"/greet.GreetService/GreetManyTimes", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Origin", tt.origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,authorization")
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if got := res.Header.Get("Access-Control-Allow-Origin"); got != tt.allow {
+			t.Errorf("preflight from %s: Access-Control-Allow-Origin = %q, want %q", tt.origin, got, tt.allow)
+		}
+	}
+}
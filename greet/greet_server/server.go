@@ -2,130 +2,114 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"github.com/simplesteph/grpc-go-course/greet/greetpb"
+	"flag"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"io"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/greet/greetservice"
+	"grpc-go-course/internal/auth"
+	"grpc-go-course/internal/authz"
+	"grpc-go-course/internal/bootstrap"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/grpcweb"
+	"grpc-go-course/internal/healthcheck"
+	"grpc-go-course/internal/logging"
+	"grpc-go-course/internal/metrics"
+	"grpc-go-course/internal/tlsconfig"
+	"grpc-go-course/internal/tracing"
+	"grpc-go-course/internal/validate"
 	"log"
+	"log/slog"
 	"net"
-	"strconv"
-	"time"
+	"os"
 )
 
-type server struct{}
-
 func main() {
-	fmt.Println("start greet")
-
-	lis, err := net.Listen("tcp", "0.0.0.0:50051")
-
+	defaults := config.ServerDefaults("0.0.0.0:50051")
+	defaults.Metrics.Listen = "0.0.0.0:9091"
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:], config.Options{
+		Role:      config.Server,
+		EnvPrefix: "GREET",
+		Defaults:  defaults,
+	})
 	if err != nil {
-		log.Fatalf("FAILED TO LISTEN %v", err)
+		log.Fatalf("%v", err)
 	}
 
-	s := grpc.NewServer()
-	greetpb.RegisterGreetServiceServer(s, &server{})
-
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	logger, err := logging.New(os.Stderr, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("%v", err)
 	}
-}
-
-func (s server) Greet(context context.Context, request *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
-	fmt.Printf("Greet function was invoked with %v\n", request)
+	slog.SetDefault(logger)
 
-	firstName := request.GetGreeting().GetFirstName()
-	lastName := request.GetGreeting().GetLastName()
+	lis, err := net.Listen("tcp", cfg.ListenAddr)
 
-	result := firstName + " " + lastName
-
-	response := &greetpb.GreetResponse{Result: result}
-
-	return response, nil
-}
-
-func (*server) GreetManyTimes(request *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
-	fmt.Printf("GreetManyTimes function was invoked with: %v\n", request)
-
-	firstName := request.GetGreeting().GetFirstName()
-	lastName := request.GetGreeting().GetLastName()
-
-	for i := 0; i < 10; i++ {
-		result := strconv.Itoa(i) + ": " + firstName + " " + lastName
-		response := &greetpb.GreetManytimesResponse{Result: result}
-		stream.SendMsg(response)
-		//TODO: research: time changing does not affect
-		time.Sleep(5 * time.Second)
+	if err != nil {
+		log.Fatalf("FAILED TO LISTEN %v", err)
 	}
 
-	return nil
-}
+	checker := healthcheck.New(greetservice.ServiceName)
+	runner := bootstrap.New(bootstrap.Options{
+		Name:         "greet",
+		DrainTimeout: cfg.DrainTimeout,
+		Health:       checker,
+	})
 
-func (s server) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
-	fmt.Printf("LongGreet was invoked with a streaming reqeuest\n")
-	result := ""
-	for {
-		request, error := stream.Recv()
-		if error == io.EOF {
-			// finished reading the client stream
-			return stream.SendAndClose(&greetpb.LongGreetResponse{
-				Result: result,
-			})
-		}
-
-		// handle error
-		if error != nil {
-			log.Fatalf("error while reading client stream: %v\n", error)
+	recorder := metrics.New()
+	if cfg.Metrics.Listen != "" {
+		metricsServer, err := recorder.ListenAndServe(cfg.Metrics.Listen)
+		if err != nil {
+			log.Fatalf("failed to serve metrics: %v", err)
 		}
+		defer metricsServer.Close()
+	}
 
-		firstName := request.GetGreeting().GetFirstName()
-		lastName := request.GetGreeting().GetLastName()
-		result += "Hello " + firstName + " " + lastName + "!\n"
+	tracer, err := tracing.Setup(context.Background(), cfg.Tracing, "greet_server")
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
 	}
-}
+	defer tracer.Shutdown(context.Background())
 
-func (s server) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) error {
-	fmt.Printf("GreetEveryone function was invoked with a streaming request\n")
+	serverTLS, err := tlsconfig.ServerConfig(context.Background(), cfg.TLS)
+	if err != nil {
+		log.Fatalf("failed to set up TLS: %v", err)
+	}
 
-	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
-			return nil
-		}
+	opts := append(cfg.ServerOptions(), tlsconfig.ServerOptions(serverTLS)...)
+	opts = append(opts, runner.ServerOptions()...)
+	opts = append(opts, recorder.ServerOptions()...)
+	opts = append(opts, logging.NewInterceptor(logger, logging.Options{
+		Payloads: cfg.LogPayloads,
+		Redact:   cfg.LogRedact,
+	}).ServerOptions()...)
+	opts = append(opts, tracer.ServerOptions()...)
+	if cfg.Auth.Enabled {
+		authenticator, err := auth.New(cfg.Auth)
 		if err != nil {
-			log.Fatalf("error while reading client stream: %v", err)
+			log.Fatalf("failed to set up authentication: %v", err)
 		}
-
-		firstName := req.Greeting.GetFirstName()
-		lastName := req.Greeting.GetLastName()
-		result := "Hello " + firstName + " " + lastName + "!\n"
-
-		sendErr := stream.Send(&greetpb.GreetEveryoneResponse{
-			Result: result,
-		})
-		if sendErr != nil {
-			log.Fatalf("error while sending data to client: %v", sendErr)
-			return err
+		opts = append(opts, authenticator.ServerOptions()...)
+	}
+	if cfg.Authz.PolicyFile != "" {
+		policy, err := authz.LoadPolicy(cfg.Authz.PolicyFile)
+		if err != nil {
+			log.Fatalf("failed to set up authorization: %v", err)
 		}
+		opts = append(opts, authz.NewEnforcer(policy, cfg.Auth.PublicMethods).ServerOptions()...)
 	}
-}
+	opts = append(opts, validate.NewInterceptor(validate.Options{}).ServerOptions()...)
 
-func (*server) GreetWithDeadline(ctx context.Context, req *greetpb.GreetWithDeadlineRequest) (*greetpb.GreetWithDeadlineResponse, error) {
-	fmt.Printf("GreetWithDeadline function was invoked with %v\n", req)
-	for i := 0; i < 3; i++ {
-		if ctx.Err() == context.DeadlineExceeded {
-			// the client canceled the request
-			fmt.Println("The client canceled the request!")
-			return nil, status.Error(codes.Canceled, "the client canceled the request")
+	s := grpc.NewServer(opts...)
+	greetpb.RegisterGreetServiceServer(s, &greetservice.Server{})
+	bootstrap.RegisterFeatures(s, cfg.Features, checker)
+	if cfg.GRPCWeb.Listen != "" {
+		webServer, webListener, err := grpcweb.Listen(cfg.GRPCWeb.Listen, grpcweb.NewHandler(s, cfg.GRPCWeb.AllowedOrigins), serverTLS)
+		if err != nil {
+			log.Fatalf("failed to serve gRPC-Web: %v", err)
 		}
-		time.Sleep(1 * time.Second)
+		runner.AddHTTPServer(webServer, webListener)
 	}
-	firstName := req.GetGreeting().GetFirstName()
-	result := "Hello " + firstName
-	res := &greetpb.GreetWithDeadlineResponse{
-		Result: result,
+
+	if err := runner.Serve(s, lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
 	}
-	return res, nil
 }
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/greet/greetservice"
+	"grpc-go-course/internal/grpctest"
+	"grpc-go-course/internal/rpcerr"
+	"grpc-go-course/internal/validate"
+	"testing"
+	"time"
+)
+
+// startTestServer serves GreetService over an in-memory listener and returns a
+// client together with a channel that receives the error of every finished stream handler.
+func startTestServer(t *testing.T) (greetpb.GreetServiceClient, <-chan error) {
+	t.Helper()
+
+	handlerErrs := make(chan error, 16)
+	opts := validate.NewInterceptor(validate.Options{}).ServerOptions()
+	s := grpc.NewServer(append(opts, grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		handlerErrs <- err
+		return err
+	}))...)
+	greetpb.RegisterGreetServiceServer(s, &greetservice.Server{})
+
+	return greetpb.NewGreetServiceClient(grpctest.Dial(t, s)), handlerErrs
+}
+
+func waitHandlerCode(t *testing.T, handlerErrs <-chan error, want codes.Code) {
+	t.Helper()
+
+	select {
+	case err := <-handlerErrs:
+		if got := status.Code(err); got != want {
+			t.Fatalf("handler returned %v (%v), want %v", got, err, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("handler did not return after the client canceled")
+	}
+}
+
+func assertStillServing(t *testing.T, c greetpb.GreetServiceClient) {
+	t.Helper()
+
+	res, err := c.Greet(context.Background(), &greetpb.GreetRequest{
+		Greeting: &greetpb.Greeting{FirstName: "John", LastName: "Johnson"},
+	})
+	if err != nil {
+		t.Fatalf("server stopped serving after a canceled stream: %v", err)
+	}
+	if res.GetResult() != "John Johnson" {
+		t.Fatalf("unexpected Greet result %q", res.GetResult())
+	}
+}
+
+func TestLongGreetSurvivesCanceledClient(t *testing.T) {
+	c, handlerErrs := startTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.LongGreet(ctx)
+	if err != nil {
+		t.Fatalf("error while calling LongGreet: %v", err)
+	}
+	if err := stream.Send(&greetpb.LongGreetRequest{Greeting: &greetpb.Greeting{FirstName: "Mike"}}); err != nil {
+		t.Fatalf("error while sending: %v", err)
+	}
+	cancel()
+
+	waitHandlerCode(t, handlerErrs, codes.Canceled)
+	assertStillServing(t, c)
+}
+
+func TestGreetEveryoneSurvivesCanceledClient(t *testing.T) {
+	c, handlerErrs := startTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.GreetEveryone(ctx)
+	if err != nil {
+		t.Fatalf("error while calling GreetEveryone: %v", err)
+	}
+	if err := stream.Send(&greetpb.GreetEveryoneRequest{Greeting: &greetpb.Greeting{FirstName: "Mike"}}); err != nil {
+		t.Fatalf("error while sending: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("error while reading: %v", err)
+	}
+	cancel()
+
+	waitHandlerCode(t, handlerErrs, codes.Canceled)
+	assertStillServing(t, c)
+}
+
+func TestGreetManyTimesSurvivesCanceledClient(t *testing.T) {
+	c, handlerErrs := startTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.GreetManyTimes(ctx, &greetpb.GreetManyTimesRequest{
+		Greeting: &greetpb.Greeting{FirstName: "John"},
+		Times:    100,
+		Interval: durationpb.New(50 * time.Millisecond),
+	})
+	if err != nil {
+		t.Fatalf("error while calling GreetManyTimes: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("error while reading: %v", err)
+	}
+	cancel()
+
+	waitHandlerCode(t, handlerErrs, codes.Canceled)
+	assertStillServing(t, c)
+}
+
+func TestGreetRejectsInvalidGreetings(t *testing.T) {
+	c, _ := startTestServer(t)
+
+	for _, tt := range []struct {
+		req   *greetpb.GreetRequest
+		field string
+	}{
+		{&greetpb.GreetRequest{}, "greeting"},
+		{&greetpb.GreetRequest{Greeting: &greetpb.Greeting{LastName: "Johnson"}}, "greeting.first_name"},
+		{&greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "<script>"}}, "greeting.first_name"},
+	} {
+		_, err := c.Greet(context.Background(), tt.req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Greet(%v) returned %v, want InvalidArgument", tt.req, err)
+			continue
+		}
+		if _, ok := rpcerr.Violation(err, tt.field); !ok {
+			t.Errorf("Greet(%v) returned %v, want a violation of %s", tt.req, err, tt.field)
+		}
+	}
+}
+
+func TestLongGreetRejectsInvalidGreetings(t *testing.T) {
+	c, handlerErrs := startTestServer(t)
+
+	stream, err := c.LongGreet(context.Background())
+	if err != nil {
+		t.Fatalf("error while calling LongGreet: %v", err)
+	}
+	if err := stream.Send(&greetpb.LongGreetRequest{Greeting: &greetpb.Greeting{FirstName: "John"}}); err != nil {
+		t.Fatalf("error while sending: %v", err)
+	}
+	if err := stream.Send(&greetpb.LongGreetRequest{}); err != nil {
+		t.Fatalf("error while sending: %v", err)
+	}
+
+	_, err = stream.CloseAndRecv()
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("LongGreet returned %v, want InvalidArgument", err)
+	}
+	if _, ok := rpcerr.Violation(err, "greeting"); !ok {
+		t.Errorf("LongGreet returned %v, want a violation of greeting", err)
+	}
+	waitHandlerCode(t, handlerErrs, codes.InvalidArgument)
+}
+
+func TestGreetManyTimesChecksItsBoundsWithoutTheInterceptor(t *testing.T) {
+	s := grpc.NewServer()
+	greetpb.RegisterGreetServiceServer(s, &greetservice.Server{})
+	c := greetpb.NewGreetServiceClient(grpctest.Dial(t, s))
+	greeting := &greetpb.Greeting{FirstName: "Ada"}
+
+	tests := map[string]struct {
+		req   *greetpb.GreetManyTimesRequest
+		field string
+	}{
+		"too many times":    {&greetpb.GreetManyTimesRequest{Greeting: greeting, Times: 1001}, "times"},
+		"negative times":    {&greetpb.GreetManyTimesRequest{Greeting: greeting, Times: -1}, "times"},
+		"negative interval": {&greetpb.GreetManyTimesRequest{Greeting: greeting, Times: 2, Interval: durationpb.New(-time.Second)}, "interval"},
+		"invalid interval":  {&greetpb.GreetManyTimesRequest{Greeting: greeting, Times: 2, Interval: &durationpb.Duration{Seconds: 1, Nanos: -1}}, "interval"},
+	}
+
+	for name, tt := range tests {
+		stream, err := c.GreetManyTimes(context.Background(), tt.req)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: GreetManyTimes returned %v, want InvalidArgument", name, err)
+			continue
+		}
+		if _, ok := rpcerr.Violation(err, tt.field); !ok {
+			t.Errorf("%s: violations = %v, want one on %s", name, rpcerr.FieldViolations(err), tt.field)
+		}
+	}
+}
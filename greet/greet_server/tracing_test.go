@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/greet/greetservice"
+	"grpc-go-course/internal/grpctest"
+	"grpc-go-course/internal/tracing"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFileProvider exports every span of service to path as soon as it ends.
+func newFileProvider(t *testing.T, path string, service string) *sdktrace.TracerProvider {
+	t.Helper()
+
+	exporter, err := tracing.NewFileExporter(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(service))),
+	)
+}
+
+type exportedSpan struct {
+	service string
+	span    *tracepb.Span
+}
+
+func readSpans(t *testing.T, path string) map[string]exportedSpan {
+	t.Helper()
+
+	resourceSpans, err := tracing.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := make(map[string]exportedSpan)
+	for _, rs := range resourceSpans {
+		service := ""
+		for _, attr := range rs.GetResource().GetAttributes() {
+			if attr.GetKey() == string(semconv.ServiceNameKey) {
+				service = attr.GetValue().GetStringValue()
+			}
+		}
+		for _, ss := range rs.GetScopeSpans() {
+			for _, span := range ss.GetSpans() {
+				spans[span.GetName()+"@"+service] = exportedSpan{service: service, span: span}
+			}
+		}
+	}
+
+	return spans
+}
+
+func TestLongGreetProducesOneTraceAcrossClientAndServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	serverProvider := newFileProvider(t, path, "greet_server")
+	clientProvider := newFileProvider(t, path, "greet_client")
+
+	s := grpc.NewServer(tracing.New(serverProvider).ServerOptions()...)
+	greetpb.RegisterGreetServiceServer(s, &greetservice.Server{})
+	conn := grpctest.Dial(t, s, tracing.New(clientProvider).DialOptions()...)
+
+	stream, err := greetpb.NewGreetServiceClient(conn).LongGreet(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Ann", "Bob", "Cid"} {
+		if err := stream.Send(&greetpb.LongGreetRequest{Greeting: &greetpb.Greeting{FirstName: name}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Fatal(err)
+	}
+
+	s.GracefulStop()
+	for _, tp := range []*sdktrace.TracerProvider{clientProvider, serverProvider} {
+		if err := tp.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spans := readSpans(t, path)
+	client, ok := spans["greet.GreetService/LongGreet@greet_client"]
+	if !ok {
+		t.Fatalf("no client span in %v", spans)
+	}
+	srv, ok := spans["greet.GreetService/LongGreet@greet_server"]
+	if !ok {
+		t.Fatalf("no server span in %v", spans)
+	}
+
+	if len(client.span.GetParentSpanId()) != 0 {
+		t.Errorf("client span has parent %s, want a root span", hex.EncodeToString(client.span.GetParentSpanId()))
+	}
+	if client.span.GetKind() != tracepb.Span_SPAN_KIND_CLIENT || srv.span.GetKind() != tracepb.Span_SPAN_KIND_SERVER {
+		t.Errorf("span kinds are %v and %v", client.span.GetKind(), srv.span.GetKind())
+	}
+	if hex.EncodeToString(srv.span.GetTraceId()) != hex.EncodeToString(client.span.GetTraceId()) {
+		t.Errorf("server trace %x differs from client trace %x", srv.span.GetTraceId(), client.span.GetTraceId())
+	}
+	if hex.EncodeToString(srv.span.GetParentSpanId()) != hex.EncodeToString(client.span.GetSpanId()) {
+		t.Errorf("server span parent %x, want the client span %x", srv.span.GetParentSpanId(), client.span.GetSpanId())
+	}
+
+	// Three greetings received and one response sent, as seen from each side.
+	assertMessageEvents(t, srv.span, map[string]int{"RECEIVED": 3, "SENT": 1})
+	assertMessageEvents(t, client.span, map[string]int{"SENT": 3, "RECEIVED": 1})
+
+	// OTLP/JSON writes ids in hex, not in the base64 of the protobuf mapping
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"traceId":"` + hex.EncodeToString(client.span.GetTraceId()) + `"`; !strings.Contains(string(data), want) {
+		t.Errorf("trace file does not contain %s:\n%s", want, data)
+	}
+}
+
+func TestCanceledStreamEndsTheClientSpan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	clientProvider := newFileProvider(t, path, "greet_client")
+
+	s := grpc.NewServer()
+	greetpb.RegisterGreetServiceServer(s, &greetservice.Server{})
+	conn := grpctest.Dial(t, s, tracing.New(clientProvider).DialOptions()...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := greetpb.NewGreetServiceClient(conn).GreetManyTimes(ctx, &greetpb.GreetManyTimesRequest{
+		Greeting: &greetpb.Greeting{FirstName: "Ada"},
+		Times:    1000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	// the caller gives up without reading the stream to its end
+	cancel()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if client, ok := readSpans(t, path)["greet.GreetService/GreetManyTimes@greet_client"]; ok {
+			if client.span.GetStatus().GetCode() != tracepb.Status_STATUS_CODE_ERROR {
+				t.Errorf("client span status is %v, want an error", client.span.GetStatus())
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the client span did not end when its context was canceled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func assertMessageEvents(t *testing.T, span *tracepb.Span, want map[string]int) {
+	t.Helper()
+
+	got := make(map[string]int)
+	for _, event := range span.GetEvents() {
+		if event.GetName() != "message" {
+			continue
+		}
+		for _, attr := range event.GetAttributes() {
+			if attr.GetKey() == string(semconv.MessageTypeKey) {
+				got[attr.GetValue().GetStringValue()]++
+			}
+		}
+	}
+
+	for typ, n := range want {
+		if got[typ] != n {
+			t.Errorf("%s span has %d %s message events, want %d", span.GetKind(), got[typ], typ, n)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Package greetservice implements GreetService, served by greet_server and
+// reached through the REST gateway.
+package greetservice
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/internal/logging"
+	"grpc-go-course/internal/rpcerr"
+	"grpc-go-course/internal/streamerr"
+	"grpc-go-course/internal/validate"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ServiceName is the full name of GreetService, as reported by health checks.
+const ServiceName = "greet.GreetService"
+
+const (
+	defaultGreetManyTimes    = 10
+	defaultGreetManyInterval = 1 * time.Second
+)
+
+// maxGreetManyTimes is the largest times the validation rules allow.
+var maxGreetManyTimes = int(validate.Rules(&greetpb.GreetManyTimesRequest{}, "times").GetMax())
+
+// Server implements greetpb.GreetServiceServer.
+type Server struct{}
+
+func (s Server) Greet(context context.Context, request *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
+	firstName := request.GetGreeting().GetFirstName()
+	lastName := request.GetGreeting().GetLastName()
+
+	result := firstName + " " + lastName
+
+	response := &greetpb.GreetResponse{Result: result}
+
+	return response, nil
+}
+
+func (*Server) GreetManyTimes(request *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
+	// checked by the validation rules too, see package validate
+	times := int(request.GetTimes())
+	if times == 0 {
+		times = defaultGreetManyTimes
+	}
+	if times < 0 || times > maxGreetManyTimes {
+		return rpcerr.InvalidArgument("times", "must be between 1 and %d, got %d", maxGreetManyTimes, times)
+	}
+
+	interval := defaultGreetManyInterval
+	if request.Interval != nil {
+		if err := request.GetInterval().CheckValid(); err != nil {
+			return rpcerr.InvalidArgument("interval", "is not a valid duration: %v", err)
+		}
+		interval = request.GetInterval().AsDuration()
+		if interval < 0 {
+			return rpcerr.InvalidArgument("interval", "must not be negative, got %v", interval)
+		}
+	}
+
+	firstName := request.GetGreeting().GetFirstName()
+	lastName := request.GetGreeting().GetLastName()
+
+	for i := 0; i < times; i++ {
+		if i > 0 {
+			// wait between greetings, but give up as soon as the client goes away
+			select {
+			case <-stream.Context().Done():
+				return status.FromContextError(stream.Context().Err()).Err()
+			case <-time.After(interval):
+			}
+		}
+
+		result := strconv.Itoa(i) + ": " + firstName + " " + lastName
+		response := &greetpb.GreetManyTimesResponse{Result: result}
+		if err := stream.Send(response); err != nil {
+			return streamerr.Send(stream, err)
+		}
+	}
+
+	return nil
+}
+
+func (s Server) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
+	result := ""
+	for {
+		request, error := stream.Recv()
+		if error == io.EOF {
+			// finished reading the client stream
+			return stream.SendAndClose(&greetpb.LongGreetResponse{
+				Result: result,
+			})
+		}
+
+		// handle error
+		if error != nil {
+			return streamerr.Recv(stream, error)
+		}
+
+		firstName := request.GetGreeting().GetFirstName()
+		lastName := request.GetGreeting().GetLastName()
+		result += "Hello " + firstName + " " + lastName + "!\n"
+	}
+}
+
+func (s Server) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return streamerr.Recv(stream, err)
+		}
+
+		firstName := req.GetGreeting().GetFirstName()
+		lastName := req.GetGreeting().GetLastName()
+		result := "Hello " + firstName + " " + lastName + "!\n"
+
+		// answer every message as soon as it arrives
+		sendErr := stream.Send(&greetpb.GreetEveryoneResponse{
+			Result: result,
+		})
+		if sendErr != nil {
+			return streamerr.Send(stream, sendErr)
+		}
+	}
+}
+
+func (*Server) GreetWithDeadline(ctx context.Context, req *greetpb.GreetWithDeadlineRequest) (*greetpb.GreetWithDeadlineResponse, error) {
+	for i := 0; i < 3; i++ {
+		if ctx.Err() == context.DeadlineExceeded {
+			// the client canceled the request
+			logging.FromContext(ctx).Info("client canceled the request")
+			return nil, status.Error(codes.Canceled, "the client canceled the request")
+		}
+		time.Sleep(1 * time.Second)
+	}
+	firstName := req.GetGreeting().GetFirstName()
+	result := "Hello " + firstName
+	res := &greetpb.GreetWithDeadlineResponse{
+		Result: result,
+	}
+	return res, nil
+}
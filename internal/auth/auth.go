@@ -0,0 +1,262 @@
+// Package auth authenticates every call from the authorization metadata,
+// either against static API keys or by verifying an HS256/RS256 JWT, and
+// hands the verified identity to handlers through the context.
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/logging"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// MetadataKey is the metadata entry that carries the credentials.
+const MetadataKey = "authorization"
+
+// Identity is the verified caller of an RPC.
+type Identity struct {
+	Subject string
+	Roles   []string
+	// Method tells how the caller authenticated: "api-key" or "jwt".
+	Method string
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of ctx that carries id.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the identity verified for the current call, if any.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
+
+// Authenticator verifies the credentials of incoming calls.
+type Authenticator struct {
+	apiKeys       []config.APIKey
+	hmacSecret    []byte
+	rsaPublicKey  *rsa.PublicKey
+	issuer        string
+	audience      string
+	rolesClaim    string
+	publicMethods []string
+}
+
+// New builds an Authenticator from the auth configuration, reading the JWT keys from disk.
+func New(cfg config.Auth) (*Authenticator, error) {
+	a := &Authenticator{
+		apiKeys:       cfg.APIKeys,
+		issuer:        cfg.JWT.Issuer,
+		audience:      cfg.JWT.Audience,
+		rolesClaim:    cfg.JWT.RolesClaim,
+		publicMethods: cfg.PublicMethods,
+	}
+
+	if cfg.JWT.HMACSecretFile != "" {
+		secret, err := os.ReadFile(cfg.JWT.HMACSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading HMAC secret: %w", err)
+		}
+		a.hmacSecret = []byte(strings.TrimSpace(string(secret)))
+		if len(a.hmacSecret) == 0 {
+			return nil, fmt.Errorf("HMAC secret %s is empty", cfg.JWT.HMACSecretFile)
+		}
+	}
+
+	if cfg.JWT.RSAPublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.JWT.RSAPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading RSA public key: %w", err)
+		}
+		if a.rsaPublicKey, err = jwt.ParseRSAPublicKeyFromPEM(data); err != nil {
+			return nil, fmt.Errorf("parsing RSA public key %s: %w", cfg.JWT.RSAPublicKeyFile, err)
+		}
+	}
+
+	return a, nil
+}
+
+// ServerOptions returns the interceptors that authenticate unary and streaming calls.
+func (a *Authenticator) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(a.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(a.StreamServerInterceptor),
+	}
+}
+
+// UnaryServerInterceptor rejects unauthenticated unary calls with codes.Unauthenticated.
+func (a *Authenticator) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects unauthenticated streams with codes.Unauthenticated.
+func (a *Authenticator) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// serverStream replaces the context of a stream with one carrying the identity.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if IsPublicMethod(a.publicMethods, method) {
+		return ctx, nil
+	}
+
+	id, err := a.Verify(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Warn("authentication failed", slog.Any("error", err))
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return logging.With(NewContext(ctx, id), slog.String("subject", id.Subject)), nil
+}
+
+// IsPublicMethod reports whether method is listed in publicMethods, either by
+// its full name or through a service prefix ending in "/".
+func IsPublicMethod(publicMethods []string, method string) bool {
+	for _, public := range publicMethods {
+		if method == public || (strings.HasSuffix(public, "/") && strings.HasPrefix(method, public)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Verify checks the credentials in the incoming metadata of ctx.
+func (a *Authenticator) Verify(ctx context.Context) (*Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return nil, errors.New("missing authorization metadata")
+	}
+	if len(values) > 1 {
+		return nil, errors.New("more than one authorization metadata value")
+	}
+
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+		return nil, errors.New("malformed authorization metadata, want \"Bearer <token>\" or \"ApiKey <key>\"")
+	}
+
+	scheme, credential := parts[0], strings.TrimSpace(parts[1])
+
+	switch strings.ToLower(scheme) {
+	case "apikey":
+		return a.verifyAPIKey(credential)
+	case "bearer":
+		// a bearer credential is either one of the static keys or a JWT
+		if id, err := a.verifyAPIKey(credential); err == nil {
+			return id, nil
+		}
+		return a.verifyJWT(credential)
+	default:
+		return nil, fmt.Errorf("unsupported authorization scheme %q", scheme)
+	}
+}
+
+func (a *Authenticator) verifyAPIKey(key string) (*Identity, error) {
+	for _, candidate := range a.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(candidate.Key), []byte(key)) == 1 {
+			return &Identity{Subject: candidate.Subject, Roles: candidate.Roles, Method: "api-key"}, nil
+		}
+	}
+
+	return nil, errors.New("unknown API key")
+}
+
+func (a *Authenticator) verifyJWT(raw string) (*Identity, error) {
+	var methods []string
+	if a.hmacSecret != nil {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if a.rsaPublicKey != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("invalid credentials")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.Alg() {
+		case jwt.SigningMethodHS256.Alg():
+			return a.hmacSecret, nil
+		case jwt.SigningMethodRS256.Alg():
+			return a.rsaPublicKey, nil
+		}
+		return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+	}, jwt.WithValidMethods(methods))
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	// ParseWithClaims only checks exp when the token has one, and a token
+	// without it would be valid forever
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("invalid token: missing exp claim")
+	}
+	if a.issuer != "" && !claims.VerifyIssuer(a.issuer, true) {
+		return nil, fmt.Errorf("invalid token: issuer is not %q", a.issuer)
+	}
+	if a.audience != "" && !claims.VerifyAudience(a.audience, true) {
+		return nil, fmt.Errorf("invalid token: audience is not %q", a.audience)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("invalid token: missing sub claim")
+	}
+
+	return &Identity{Subject: subject, Roles: stringList(claims[a.rolesClaim]), Method: "jwt"}, nil
+}
+
+// stringList accepts a claim given either as a list of strings or as a space separated string.
+func stringList(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/internal/config"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testMethod = "/calculator.CalculatorService/Sum"
+
+func newTestAuthenticator(t *testing.T) (*Authenticator, []byte, *rsa.PrivateKey) {
+	t.Helper()
+
+	dir := t.TempDir()
+	secret := []byte("a-shared-secret")
+	secretFile := filepath.Join(dir, "secret")
+	if err := os.WriteFile(secretFile, secret, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyFile := filepath.Join(dir, "public.pem")
+	if err := os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := New(config.Auth{
+		Enabled: true,
+		APIKeys: []config.APIKey{{Key: "etl-key", Subject: "etl", Roles: []string{"batch"}}},
+		JWT: config.JWT{
+			HMACSecretFile:   secretFile,
+			RSAPublicKeyFile: publicKeyFile,
+			Issuer:           "tests",
+			RolesClaim:       "roles",
+		},
+		PublicMethods: []string{"/grpc.health.v1.Health/"},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	return a, secret, rsaKey
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// call runs the unary interceptor with the given authorization value and
+// returns the identity the handler saw.
+func call(a *Authenticator, method string, authorization string) (*Identity, error) {
+	ctx := context.Background()
+	if authorization != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataKey, authorization))
+	}
+
+	var seen *Identity
+	_, err := a.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen, _ = FromContext(ctx)
+		return nil, nil
+	})
+
+	return seen, err
+}
+
+func TestAuthenticatorAccepts(t *testing.T) {
+	a, secret, rsaKey := newTestAuthenticator(t)
+	valid := jwt.MapClaims{"sub": "alice", "iss": "tests", "roles": []string{"admin"}, "exp": time.Now().Add(time.Hour).Unix()}
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          Identity
+	}{
+		{"api key scheme", "ApiKey etl-key", Identity{Subject: "etl", Roles: []string{"batch"}, Method: "api-key"}},
+		{"api key as bearer", "Bearer etl-key", Identity{Subject: "etl", Roles: []string{"batch"}, Method: "api-key"}},
+		{"HS256", "Bearer " + sign(t, jwt.SigningMethodHS256, secret, valid), Identity{Subject: "alice", Roles: []string{"admin"}, Method: "jwt"}},
+		{"RS256", "Bearer " + sign(t, jwt.SigningMethodRS256, rsaKey, valid), Identity{Subject: "alice", Roles: []string{"admin"}, Method: "jwt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := call(a, testMethod, tt.authorization)
+			if err != nil {
+				t.Fatalf("call rejected: %v", err)
+			}
+			if got == nil || !reflect.DeepEqual(*got, tt.want) {
+				t.Fatalf("handler saw identity %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticatorRejects(t *testing.T) {
+	a, secret, rsaKey := newTestAuthenticator(t)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := jwt.MapClaims{"sub": "alice", "iss": "tests", "exp": time.Now().Add(time.Hour).Unix()}
+	expired := jwt.MapClaims{"sub": "alice", "iss": "tests", "exp": time.Now().Add(-time.Hour).Unix()}
+	wrongIssuer := jwt.MapClaims{"sub": "alice", "iss": "elsewhere", "exp": time.Now().Add(time.Hour).Unix()}
+	noSubject := jwt.MapClaims{"iss": "tests", "exp": time.Now().Add(time.Hour).Unix()}
+	noExpiry := jwt.MapClaims{"sub": "alice", "iss": "tests"}
+
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{"missing metadata", ""},
+		{"unknown scheme", "Basic dXNlcjpwYXNz"},
+		{"unknown api key", "ApiKey nope"},
+		{"expired", "Bearer " + sign(t, jwt.SigningMethodHS256, secret, expired)},
+		{"no expiry", "Bearer " + sign(t, jwt.SigningMethodHS256, secret, noExpiry)},
+		{"wrong issuer", "Bearer " + sign(t, jwt.SigningMethodHS256, secret, wrongIssuer)},
+		{"missing subject", "Bearer " + sign(t, jwt.SigningMethodRS256, rsaKey, noSubject)},
+		{"wrong HMAC secret", "Bearer " + sign(t, jwt.SigningMethodHS256, []byte("guess"), valid)},
+		{"untrusted RSA key", "Bearer " + sign(t, jwt.SigningMethodRS256, otherKey, valid)},
+		{"unsupported algorithm", "Bearer " + sign(t, jwt.SigningMethodHS512, secret, valid)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := call(a, testMethod, tt.authorization)
+			if status.Code(err) != codes.Unauthenticated {
+				t.Fatalf("got %v, want Unauthenticated", err)
+			}
+		})
+	}
+}
+
+func TestPublicMethodsNeedNoCredentials(t *testing.T) {
+	a, _, _ := newTestAuthenticator(t)
+
+	if _, err := call(a, "/grpc.health.v1.Health/Check", ""); err != nil {
+		t.Fatalf("health check was rejected: %v", err)
+	}
+}
+
+func TestAuthenticatorRejectsSeveralCredentials(t *testing.T) {
+	a, _, _ := newTestAuthenticator(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "ApiKey etl-key", MetadataKey, "ApiKey etl-key"))
+
+	if _, err := a.Verify(ctx); err == nil {
+		t.Fatalf("two authorization values were accepted")
+	}
+}
+
+func TestTokenCredentialsKeepTheCallersToken(t *testing.T) {
+	creds := TokenCredentials{Token: "gateway-key"}
+
+	md, err := creds.GetRequestMetadata(context.Background())
+	if err != nil || md[MetadataKey] != "Bearer gateway-key" {
+		t.Errorf("a call without credentials got %v (%v)", md, err)
+	}
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(MetadataKey, "Bearer caller-key"))
+	md, err = creds.GetRequestMetadata(ctx)
+	if err != nil || len(md) != 0 {
+		t.Errorf("a call with the caller's credentials got %v (%v)", md, err)
+	}
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"grpc-go-course/internal/config"
+	"os"
+	"strings"
+)
+
+// TokenCredentials attaches a bearer token to every call that carries no
+// authorization metadata of its own. It implements
+// credentials.PerRPCCredentials.
+type TokenCredentials struct {
+	Token string
+	// AllowInsecure lets the token travel over connections without TLS.
+	AllowInsecure bool
+}
+
+var _ credentials.PerRPCCredentials = TokenCredentials{}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (c TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	// the server rejects calls with a second value as Unauthenticated
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKey)) > 0 {
+		return nil, nil
+	}
+
+	return map[string]string{MetadataKey: "Bearer " + c.Token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+func (c TokenCredentials) RequireTransportSecurity() bool {
+	return !c.AllowInsecure
+}
+
+// DialOptions returns the option that attaches the configured token, or
+// nothing when the client has no token.
+func DialOptions(cfg config.Auth) ([]grpc.DialOption, error) {
+	token := cfg.Token
+	if cfg.TokenFile != "" {
+		data, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token == "" {
+		return nil, nil
+	}
+
+	return []grpc.DialOption{
+		grpc.WithPerRPCCredentials(TokenCredentials{Token: token, AllowInsecure: cfg.AllowInsecure}),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package authz
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/internal/auth"
+	"grpc-go-course/internal/logging"
+	"log/slog"
+)
+
+// Enforcer applies a Policy to every call. It must run after the auth
+// interceptors so that the caller identity is in the context.
+type Enforcer struct {
+	policy *Policy
+	// publicMethods are exempt, just like they are exempt from authentication.
+	publicMethods []string
+}
+
+// NewEnforcer creates an Enforcer for policy that lets publicMethods through unchecked.
+func NewEnforcer(policy *Policy, publicMethods []string) *Enforcer {
+	return &Enforcer{policy: policy, publicMethods: publicMethods}
+}
+
+// ServerOptions returns the interceptors that enforce the policy.
+func (e *Enforcer) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(e.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(e.StreamServerInterceptor),
+	}
+}
+
+// UnaryServerInterceptor rejects unary calls the policy denies with codes.PermissionDenied.
+func (e *Enforcer) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := e.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(context.WithValue(ctx, enforcerKey{}, e), req)
+}
+
+// StreamServerInterceptor rejects streams the policy denies with codes.PermissionDenied.
+func (e *Enforcer) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := e.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), enforcerKey{}, e)})
+}
+
+// serverStream replaces the context of a stream with one carrying the Enforcer.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+type enforcerKey struct{}
+
+// Authorize applies the policy that admitted the current call to method, for
+// handlers that do the work of other RPCs on behalf of their caller, such as
+// batches. It returns nil when no Enforcer admitted the call.
+func Authorize(ctx context.Context, method string) error {
+	e, ok := ctx.Value(enforcerKey{}).(*Enforcer)
+	if !ok {
+		return nil
+	}
+
+	return e.authorize(ctx, method)
+}
+
+func (e *Enforcer) authorize(ctx context.Context, method string) error {
+	if auth.IsPublicMethod(e.publicMethods, method) {
+		return nil
+	}
+
+	logger := logging.FromContext(ctx)
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		logger.Warn("authorization decision", slog.Bool("audit", true), slog.String("decision", string(Deny)),
+			slog.String("authz_method", method), slog.String("rule", "unauthenticated"))
+		return status.Error(codes.PermissionDenied, "no verified identity for "+method)
+	}
+
+	decision := e.policy.Decide(id, method)
+	logger.Info("authorization decision", slog.Bool("audit", true), slog.String("decision", string(decision.Effect)),
+		slog.String("authz_method", method), slog.String("subject", id.Subject), slog.Any("roles", id.Roles),
+		slog.String("rule", decision.Rule))
+	if !decision.Allowed() {
+		return status.Errorf(codes.PermissionDenied, "%q may not call %s", id.Subject, method)
+	}
+
+	return nil
+}
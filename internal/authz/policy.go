@@ -0,0 +1,194 @@
+// Package authz decides which authenticated callers may invoke which RPCs.
+// Decisions come from a policy file that maps subjects and roles to full
+// method names such as /calculator.CalculatorService/SquareRoot:
+//
+//	default: deny
+//	rules:
+//	  - name: admins
+//	    effect: allow
+//	    roles: [admin]
+//	    methods: ["*"]
+//	  - name: no-factorization-for-etl
+//	    effect: deny
+//	    subjects: [etl]
+//	    methods: [/calculator.CalculatorService/PrimeNumberDecomposition]
+//	  - name: basic
+//	    effect: allow
+//	    roles: [basic]
+//	    methods: [/calculator.CalculatorService/Sum, /greet.GreetService/Greet]
+//
+// A matching deny rule always wins over a matching allow rule; when no rule
+// matches, the default effect applies. In subjects, roles and methods "*"
+// matches any sequence of characters.
+package authz
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"grpc-go-course/internal/auth"
+	"os"
+	"strings"
+)
+
+// Effect is the outcome of a rule.
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+)
+
+// Rule grants or refuses access to methods for the listed subjects or roles.
+// A rule applies when the caller matches one of Subjects or one of Roles and
+// the method matches one of Methods.
+type Rule struct {
+	Name     string   `yaml:"name"`
+	Effect   Effect   `yaml:"effect"`
+	Subjects []string `yaml:"subjects"`
+	Roles    []string `yaml:"roles"`
+	Methods  []string `yaml:"methods"`
+}
+
+// Policy is an ordered set of rules plus the effect used when none matches.
+type Policy struct {
+	Default Effect `yaml:"default"`
+	Rules   []Rule `yaml:"rules"`
+}
+
+// Decision explains why a call was allowed or denied.
+type Decision struct {
+	Effect Effect
+	// Rule is the name of the deciding rule, "default" when none matched.
+	Rule string
+}
+
+// Allowed reports whether the decision lets the call through.
+func (d Decision) Allowed() bool {
+	return d.Effect == Allow
+}
+
+// LoadPolicy reads and validates a policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy: %w", err)
+	}
+
+	policy := &Policy{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("parsing policy %s: %w", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+
+	return policy, nil
+}
+
+// Validate checks that every rule can ever match and has a known effect.
+func (p *Policy) Validate() error {
+	if p.Default == "" {
+		p.Default = Deny
+	}
+	if p.Default != Allow && p.Default != Deny {
+		return fmt.Errorf("default must be allow or deny, got %q", p.Default)
+	}
+
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rules[%d]", i)
+		}
+		if rule.Effect != Allow && rule.Effect != Deny {
+			return fmt.Errorf("%s: effect must be allow or deny, got %q", rule.Name, rule.Effect)
+		}
+		if len(rule.Subjects) == 0 && len(rule.Roles) == 0 {
+			return fmt.Errorf("%s: needs at least one subject or role", rule.Name)
+		}
+		if len(rule.Methods) == 0 {
+			return fmt.Errorf("%s: needs at least one method", rule.Name)
+		}
+		for _, method := range rule.Methods {
+			if method != "*" && !strings.HasPrefix(method, "/") {
+				return fmt.Errorf("%s: method %q must be a full method name like /package.Service/Method", rule.Name, method)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Decide evaluates the policy for id calling method.
+func (p *Policy) Decide(id *auth.Identity, method string) Decision {
+	var allowedBy string
+	for _, rule := range p.Rules {
+		if !rule.matches(id, method) {
+			continue
+		}
+		if rule.Effect == Deny {
+			return Decision{Effect: Deny, Rule: rule.Name}
+		}
+		if allowedBy == "" {
+			allowedBy = rule.Name
+		}
+	}
+
+	if allowedBy != "" {
+		return Decision{Effect: Allow, Rule: allowedBy}
+	}
+
+	return Decision{Effect: p.Default, Rule: "default"}
+}
+
+func (r *Rule) matches(id *auth.Identity, method string) bool {
+	if !matchAny(r.Methods, method) {
+		return false
+	}
+	if matchAny(r.Subjects, id.Subject) {
+		return true
+	}
+	for _, role := range id.Roles {
+		if matchAny(r.Roles, role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if match(pattern, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match reports whether value matches pattern, where "*" stands for any
+// sequence of characters, including "/" and ".".
+func match(pattern string, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
@@ -0,0 +1,122 @@
+package authz
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/internal/auth"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testPolicy = `
+default: deny
+rules:
+  - name: admins
+    effect: allow
+    roles: [admin]
+    methods: ["*"]
+  - name: no-expensive-calls-for-etl
+    effect: deny
+    subjects: [etl]
+    methods:
+      - /calculator.CalculatorService/PrimeNumberDecomposition
+      - /calculator.CalculatorService/FindMaximum
+  - name: batch-calculator
+    effect: allow
+    roles: [batch]
+    methods: [/calculator.CalculatorService/*]
+  - name: basic
+    effect: allow
+    roles: [basic]
+    methods: [/calculator.CalculatorService/Sum, /greet.GreetService/Greet]
+`
+
+func loadTestPolicy(t *testing.T) *Policy {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(testPolicy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("LoadPolicy: %v", err)
+	}
+
+	return policy
+}
+
+func TestPolicyDecide(t *testing.T) {
+	policy := loadTestPolicy(t)
+
+	admin := &auth.Identity{Subject: "root", Roles: []string{"admin"}}
+	etl := &auth.Identity{Subject: "etl", Roles: []string{"batch"}}
+	basic := &auth.Identity{Subject: "alice", Roles: []string{"basic"}}
+	nobody := &auth.Identity{Subject: "mallory"}
+
+	tests := []struct {
+		id     *auth.Identity
+		method string
+		want   Decision
+	}{
+		{admin, "/calculator.CalculatorService/PrimeNumberDecomposition", Decision{Allow, "admins"}},
+		{admin, "/greet.GreetService/GreetEveryone", Decision{Allow, "admins"}},
+		{etl, "/calculator.CalculatorService/Sum", Decision{Allow, "batch-calculator"}},
+		{etl, "/calculator.CalculatorService/PrimeNumberDecomposition", Decision{Deny, "no-expensive-calls-for-etl"}},
+		{etl, "/calculator.CalculatorService/FindMaximum", Decision{Deny, "no-expensive-calls-for-etl"}},
+		{etl, "/greet.GreetService/Greet", Decision{Deny, "default"}},
+		{basic, "/calculator.CalculatorService/Sum", Decision{Allow, "basic"}},
+		{basic, "/greet.GreetService/Greet", Decision{Allow, "basic"}},
+		{basic, "/calculator.CalculatorService/SquareRoot", Decision{Deny, "default"}},
+		{nobody, "/calculator.CalculatorService/Sum", Decision{Deny, "default"}},
+	}
+	for _, tt := range tests {
+		if got := policy.Decide(tt.id, tt.method); got != tt.want {
+			t.Errorf("Decide(%s, %s) = %+v, want %+v", tt.id.Subject, tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	invalid := map[string]Policy{
+		"unknown default": {Default: "maybe"},
+		"unknown effect":  {Rules: []Rule{{Effect: "perhaps", Roles: []string{"a"}, Methods: []string{"*"}}}},
+		"nobody":          {Rules: []Rule{{Effect: Allow, Methods: []string{"*"}}}},
+		"no methods":      {Rules: []Rule{{Effect: Allow, Roles: []string{"a"}}}},
+		"short method":    {Rules: []Rule{{Effect: Allow, Roles: []string{"a"}, Methods: []string{"Sum"}}}},
+	}
+	for name, policy := range invalid {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("%s: Validate accepted an invalid policy", name)
+		}
+	}
+}
+
+func TestEnforcerReturnsPermissionDenied(t *testing.T) {
+	enforcer := NewEnforcer(loadTestPolicy(t), []string{"/grpc.health.v1.Health/"})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(id *auth.Identity, method string) error {
+		ctx := context.Background()
+		if id != nil {
+			ctx = auth.NewContext(ctx, id)
+		}
+		_, err := enforcer.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	if err := call(&auth.Identity{Subject: "alice", Roles: []string{"basic"}}, "/calculator.CalculatorService/Sum"); err != nil {
+		t.Fatalf("allowed call failed: %v", err)
+	}
+	if err := call(&auth.Identity{Subject: "alice", Roles: []string{"basic"}}, "/calculator.CalculatorService/SquareRoot"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("denied call returned %v, want PermissionDenied", err)
+	}
+	if err := call(nil, "/calculator.CalculatorService/Sum"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("call without identity returned %v, want PermissionDenied", err)
+	}
+	if err := call(nil, "/grpc.health.v1.Health/Check"); err != nil {
+		t.Fatalf("public method was denied: %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+// Package bootstrap runs a grpc.Server until the process is asked to stop and then
+// drains it: in-flight calls get a bounded amount of time to finish before the
+// server is stopped hard. HTTP servers that hand calls to the grpc.Server, such
+// as the gRPC-Web one, are served and drained along with it.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/healthcheck"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// DefaultDrainTimeout is used when Options.DrainTimeout is not set.
+const DefaultDrainTimeout = 30 * time.Second
+
+// Options configures a Runner.
+type Options struct {
+	// Name identifies the server in log lines.
+	Name string
+	// DrainTimeout bounds how long GracefulStop may wait for in-flight calls
+	// before Stop cancels whatever is still running.
+	DrainTimeout time.Duration
+	// Health, when set, is switched to NOT_SERVING for every service as soon as draining starts.
+	Health *healthcheck.Checker
+	// Signals that trigger the shutdown, SIGINT and SIGTERM when empty.
+	Signals []os.Signal
+}
+
+// RegisterFeatures registers on s the optional services features switches on:
+// checker as the health service, and server reflection.
+func RegisterFeatures(s *grpc.Server, features config.Features, checker *healthcheck.Checker) {
+	if features.Health {
+		checker.Register(s)
+	}
+	if features.Reflection {
+		reflection.Register(s)
+	}
+}
+
+// Runner serves a grpc.Server and shuts it down gracefully.
+type Runner struct {
+	opts    Options
+	streams int64
+	unary   int64
+	web     []httpServer
+	// webCalls counts the requests, or h2c connections, the HTTP servers are handling.
+	webCalls int64
+}
+
+type httpServer struct {
+	srv *http.Server
+	lis net.Listener
+}
+
+// New creates a Runner. Its ServerOptions must be passed to grpc.NewServer so
+// that the Runner can tell how many calls are still open while draining.
+func New(opts Options) *Runner {
+	if opts.DrainTimeout <= 0 {
+		opts.DrainTimeout = DefaultDrainTimeout
+	}
+	if len(opts.Signals) == 0 {
+		opts.Signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	return &Runner{opts: opts}
+}
+
+// ServerOptions returns the interceptors that keep track of in-flight calls.
+func (r *Runner) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(r.unaryInterceptor),
+		grpc.ChainStreamInterceptor(r.streamInterceptor),
+	}
+}
+
+// InFlight reports the number of open streams and unary calls.
+func (r *Runner) InFlight() (streams int64, unary int64) {
+	return atomic.LoadInt64(&r.streams), atomic.LoadInt64(&r.unary)
+}
+
+// AddHTTPServer makes the Runner serve srv on lis along with the grpc.Server.
+// srv's handler must end in the grpc.Server's ServeHTTP, and must be set
+// already. grpc.Server cannot drain the calls it serves that way, so draining
+// shuts srv down first and only then stops the grpc.Server.
+func (r *Runner) AddHTTPServer(srv *http.Server, lis net.Listener) {
+	handler := srv.Handler
+	srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt64(&r.webCalls, 1)
+		defer atomic.AddInt64(&r.webCalls, -1)
+
+		handler.ServeHTTP(w, req)
+	})
+	r.web = append(r.web, httpServer{srv: srv, lis: lis})
+}
+
+// Serve accepts connections on lis until one of the configured signals arrives.
+func (r *Runner) Serve(s *grpc.Server, lis net.Listener) error {
+	return r.ServeContext(context.Background(), s, lis)
+}
+
+// ServeContext is like Serve but also starts draining when ctx is done.
+func (r *Runner) ServeContext(ctx context.Context, s *grpc.Server, lis net.Listener) error {
+	ctx, stop := signal.NotifyContext(ctx, r.opts.Signals...)
+	defer stop()
+
+	for _, web := range r.web {
+		go func(web httpServer) {
+			if err := web.srv.Serve(web.lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("HTTP listener failed", slog.String("server", r.opts.Name),
+					slog.String("addr", web.lis.Addr().String()), slog.Any("error", err))
+			}
+		}(web)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
+		r.closeHTTP()
+		return err
+	case <-ctx.Done():
+	}
+
+	r.drain(s)
+
+	return <-serveErr
+}
+
+func (r *Runner) drain(s *grpc.Server) {
+	if r.opts.Health != nil {
+		r.opts.Health.SetAllServing(false)
+	}
+
+	logger := slog.With(slog.String("server", r.opts.Name))
+	streams, unary := r.InFlight()
+	logger.Info("shutting down, draining open calls", slog.Int64("open_streams", streams),
+		slog.Int64("open_unary", unary), slog.Duration("deadline", r.opts.DrainTimeout))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.opts.DrainTimeout)
+	defer cancel()
+
+	// GracefulStop would panic on the calls served through ServeHTTP, so it only
+	// starts once the HTTP servers have no call left.
+	if err := r.shutdownHTTP(ctx); err != nil {
+		logger.Warn("drain deadline passed before the HTTP servers shut down, forcing stop",
+			slog.Int64("open_http", atomic.LoadInt64(&r.webCalls)))
+		r.closeHTTP()
+		s.Stop()
+		return
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		logger.Info("drained, all calls finished")
+	case <-ctx.Done():
+		streams, unary := r.InFlight()
+		logger.Warn("drain deadline passed, forcing stop", slog.Int64("open_streams", streams),
+			slog.Int64("open_unary", unary))
+		s.Stop()
+		<-stopped
+	}
+}
+
+// shutdownHTTP stops the HTTP servers from taking new calls and waits until the
+// open ones are over, or ctx is done.
+func (r *Runner) shutdownHTTP(ctx context.Context) error {
+	for _, web := range r.web {
+		// connections h2c hijacked are not waited for by Shutdown, but still
+		// count in webCalls
+		if err := web.srv.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for atomic.LoadInt64(&r.webCalls) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func (r *Runner) closeHTTP() {
+	for _, web := range r.web {
+		web.srv.Close()
+	}
+}
+
+func (r *Runner) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	atomic.AddInt64(&r.unary, 1)
+	defer atomic.AddInt64(&r.unary, -1)
+
+	return handler(ctx, req)
+}
+
+func (r *Runner) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	atomic.AddInt64(&r.streams, 1)
+	defer atomic.AddInt64(&r.streams, -1)
+
+	return handler(srv, ss)
+}
@@ -0,0 +1,410 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/tls"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	v1alphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/calculator/calculatorservice"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/greet/greetservice"
+	"grpc-go-course/internal/auth"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/grpctest"
+	"grpc-go-course/internal/grpcweb"
+	"grpc-go-course/internal/healthcheck"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// blockingGreeter sends a first greeting, then waits for release before it sends
+// the second and last one.
+type blockingGreeter struct {
+	greetpb.UnimplementedGreetServiceServer
+	release chan struct{}
+}
+
+func (g *blockingGreeter) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
+	if err := stream.Send(&greetpb.GreetManyTimesResponse{Result: "0"}); err != nil {
+		return err
+	}
+	select {
+	case <-g.release:
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	}
+	return stream.Send(&greetpb.GreetManyTimesResponse{Result: "1"})
+}
+
+// serve runs s through r until the returned cancel function is called; the
+// error of ServeContext then arrives on the returned channel.
+func serve(t *testing.T, r *Runner, s *grpc.Server) (net.Listener, context.CancelFunc, <-chan error) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- r.ServeContext(ctx, s, lis)
+	}()
+
+	return lis, cancel, served
+}
+
+// waitClosed waits until nothing accepts connections on addr anymore.
+func waitClosed(t *testing.T, addr string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatalf("%s still accepts connections", addr)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// startBlockedStream opens a GreetManyTimes stream to addr and reads its first
+// greeting, leaving the handler blocked.
+func startBlockedStream(t *testing.T, addr string) greetpb.GreetService_GreetManyTimesClient {
+	t.Helper()
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	stream, err := greetpb.NewGreetServiceClient(conn).GreetManyTimes(context.Background(), &greetpb.GreetManyTimesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("error while reading: %v", err)
+	}
+	return stream
+}
+
+func waitServed(t *testing.T, served <-chan error) {
+	t.Helper()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("ServeContext returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeContext did not return")
+	}
+}
+
+func TestDrainLetsOpenCallsFinish(t *testing.T) {
+	checker := healthcheck.New("greet.GreetService")
+	r := New(Options{Name: "test", DrainTimeout: 5 * time.Second, Health: checker})
+	s := grpc.NewServer(r.ServerOptions()...)
+	greeter := &blockingGreeter{release: make(chan struct{})}
+	greetpb.RegisterGreetServiceServer(s, greeter)
+	lis, cancel, served := serve(t, r, s)
+	stream := startBlockedStream(t, lis.Addr().String())
+
+	cancel()
+	waitClosed(t, lis.Addr().String())
+
+	for _, service := range []string{"", "greet.GreetService"} {
+		res, err := checker.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil || res.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("health of %q is %v (%v) while draining, want NOT_SERVING", service, res.GetStatus(), err)
+		}
+	}
+	if streams, _ := r.InFlight(); streams != 1 {
+		t.Errorf("%d streams in flight while draining, want 1", streams)
+	}
+
+	close(greeter.release)
+	if res, err := stream.Recv(); err != nil || res.GetResult() != "1" {
+		t.Fatalf("the open stream got %v (%v) while draining, want the last greeting", res, err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("the open stream did not end cleanly: %v", err)
+	}
+	waitServed(t, served)
+}
+
+func TestDrainStopsWhenTheDeadlinePasses(t *testing.T) {
+	const drainTimeout = 100 * time.Millisecond
+	r := New(Options{Name: "test", DrainTimeout: drainTimeout})
+	s := grpc.NewServer(r.ServerOptions()...)
+	greetpb.RegisterGreetServiceServer(s, &blockingGreeter{release: make(chan struct{})})
+	lis, cancel, served := serve(t, r, s)
+	stream := startBlockedStream(t, lis.Addr().String())
+
+	start := time.Now()
+	cancel()
+	waitServed(t, served)
+	if elapsed := time.Since(start); elapsed < drainTimeout {
+		t.Errorf("stopped after %v, before the drain deadline", elapsed)
+	}
+
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable && status.Code(err) != codes.Canceled {
+		t.Errorf("the blocked stream got %v, want it cut off", err)
+	}
+	// Stop cancels the handler, which may take a moment to return
+	deadline := time.Now().Add(5 * time.Second)
+	for streams, _ := r.InFlight(); streams != 0; streams, _ = r.InFlight() {
+		if time.Now().After(deadline) {
+			t.Fatalf("the blocked handler is still running after Stop")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDrainWaitsForGRPCWebStreams(t *testing.T) {
+	transports := map[string]http.RoundTripper{
+		"HTTP/1.1": &http.Transport{},
+		"h2c": &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			},
+		},
+	}
+
+	for name, transport := range transports {
+		t.Run(name, func(t *testing.T) {
+			r := New(Options{Name: "test", DrainTimeout: 5 * time.Second})
+			s := grpc.NewServer(r.ServerOptions()...)
+			greeter := &blockingGreeter{release: make(chan struct{})}
+			greetpb.RegisterGreetServiceServer(s, greeter)
+			web, webLis, err := grpcweb.Listen("127.0.0.1:0", grpcweb.NewHandler(s, nil), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.AddHTTPServer(web, webLis)
+			_, cancel, served := serve(t, r, s)
+
+			client := &grpcweb.Client{HTTPClient: &http.Client{Transport: transport}, BaseURL: "http://" + web.Addr}
+			stream, err := client.NewStream(context.Background(), "/greet.GreetService/GreetManyTimes", &greetpb.GreetManyTimesRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stream.Close()
+			var res greetpb.GreetManyTimesResponse
+			if err := stream.Recv(&res); err != nil {
+				t.Fatalf("error while reading: %v", err)
+			}
+
+			cancel()
+			waitClosed(t, web.Addr)
+			close(greeter.release)
+
+			if err := stream.Recv(&res); err != nil || res.GetResult() != "1" {
+				t.Fatalf("the open stream got %v (%v) while draining, want the last greeting", &res, err)
+			}
+			if err := stream.Recv(&res); err != io.EOF {
+				t.Fatalf("the open stream did not end cleanly: %v", err)
+			}
+			waitServed(t, served)
+		})
+	}
+}
+
+// dialFeatures serves the services register adds, and those features switches
+// on, over an in-memory listener.
+func dialFeatures(t *testing.T, features config.Features, register func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+
+	s := grpc.NewServer()
+	register(s)
+	RegisterFeatures(s, features, healthcheck.New())
+
+	return grpctest.Dial(t, s)
+}
+
+// reflectedMethods asks the reflection service of conn for the methods of
+// service, failing the test when the service is not listed.
+func reflectedMethods(t *testing.T, conn *grpc.ClientConn, service protoreflect.ServiceDescriptor) map[string]*descriptorpb.MethodDescriptorProto {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("error while calling ServerReflectionInfo: %v", err)
+	}
+	serviceName := string(service.FullName())
+
+	// the service must be listed ...
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatalf("error while sending: %v", err)
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("error while reading: %v", err)
+	}
+	listed := false
+	for _, s := range res.GetListServicesResponse().GetService() {
+		listed = listed || s.GetName() == serviceName
+	}
+	if !listed {
+		t.Fatalf("%s is not listed by reflection: %v", serviceName, res.GetListServicesResponse())
+	}
+
+	// ... and its file descriptor must describe it
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: serviceName},
+	}); err != nil {
+		t.Fatalf("error while sending: %v", err)
+	}
+	res, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("error while reading: %v", err)
+	}
+
+	methods := map[string]*descriptorpb.MethodDescriptorProto{}
+	for _, raw := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(raw, file); err != nil {
+			t.Fatalf("invalid file descriptor: %v", err)
+		}
+		for _, s := range file.GetService() {
+			if file.GetPackage()+"."+s.GetName() != serviceName {
+				continue
+			}
+			for _, method := range s.GetMethod() {
+				methods[method.GetName()] = method
+			}
+		}
+	}
+
+	return methods
+}
+
+func TestReflectionListsEveryMethod(t *testing.T) {
+	services := map[protoreflect.ServiceDescriptor]func(*grpc.Server){
+		greetpb.File_greet_greetpb_greet_proto.Services().ByName("GreetService"): func(s *grpc.Server) {
+			greetpb.RegisterGreetServiceServer(s, &greetservice.Server{})
+		},
+		calculatorpb.File_calculator_calculatorpb_calculator_proto.Services().ByName("CalculatorService"): func(s *grpc.Server) {
+			calculatorpb.RegisterCalculatorServiceServer(s, &calculatorservice.Server{})
+		},
+	}
+
+	for want, register := range services {
+		t.Run(string(want.Name()), func(t *testing.T) {
+			conn := dialFeatures(t, config.Features{Reflection: true}, register)
+			methods := reflectedMethods(t, conn, want)
+
+			if len(methods) != want.Methods().Len() {
+				t.Fatalf("reflection exposes %d methods, %s declares %d", len(methods), want.ParentFile().Path(), want.Methods().Len())
+			}
+			for i := 0; i < want.Methods().Len(); i++ {
+				method := want.Methods().Get(i)
+				got, ok := methods[string(method.Name())]
+				if !ok {
+					t.Errorf("%s is not discoverable", method.FullName())
+					continue
+				}
+				if got.GetClientStreaming() != method.IsStreamingClient() || got.GetServerStreaming() != method.IsStreamingServer() {
+					t.Errorf("%s: reflection reports client/server streaming %v/%v, want %v/%v", method.FullName(),
+						got.GetClientStreaming(), got.GetServerStreaming(), method.IsStreamingClient(), method.IsStreamingServer())
+				}
+			}
+		})
+	}
+}
+
+// listServices asks both versions of the reflection service of conn, v1 that
+// grpcurl tries first and v1alpha, for the services and returns the error of
+// each by version.
+func listServices(conn *grpc.ClientConn) map[string]error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	v1, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err == nil {
+		err = v1.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}})
+	}
+	if err == nil {
+		_, err = v1.Recv()
+	}
+	errs := map[string]error{"v1": err}
+
+	v1alpha, err := v1alphapb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err == nil {
+		err = v1alpha.Send(&v1alphapb.ServerReflectionRequest{MessageRequest: &v1alphapb.ServerReflectionRequest_ListServices{}})
+	}
+	if err == nil {
+		_, err = v1alpha.Recv()
+	}
+	errs["v1alpha"] = err
+
+	return errs
+}
+
+func TestRegisterFeaturesFollowsTheFlags(t *testing.T) {
+	register := func(s *grpc.Server) { greetpb.RegisterGreetServiceServer(s, &greetservice.Server{}) }
+
+	for _, features := range []config.Features{{}, {Reflection: true}, {Health: true}, {Reflection: true, Health: true}} {
+		conn := dialFeatures(t, features, register)
+
+		for version, err := range listServices(conn) {
+			if reflected := status.Code(err) != codes.Unimplemented; reflected != features.Reflection {
+				t.Errorf("%+v: the %s reflection service answered %v", features, version, err)
+			}
+		}
+
+		_, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if checked := status.Code(err) != codes.Unimplemented; checked != features.Health {
+			t.Errorf("%+v: the health service answered %v", features, err)
+		}
+	}
+}
+
+func TestReflectionNeedsNoCredentialsByDefault(t *testing.T) {
+	cfg := config.ServerDefaults("localhost:50051").Auth
+	cfg.Enabled = true
+	cfg.APIKeys = []config.APIKey{{Key: "key", Subject: "someone"}}
+	authenticator, err := auth.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(authenticator.ServerOptions()...)
+	greetpb.RegisterGreetServiceServer(s, &greetservice.Server{})
+	RegisterFeatures(s, config.Features{Reflection: true}, nil)
+	conn := grpctest.Dial(t, s)
+
+	for version, err := range listServices(conn) {
+		if err != nil {
+			t.Errorf("the %s reflection service answered %v without credentials", version, err)
+		}
+	}
+	_, err = greetpb.NewGreetServiceClient(conn).Greet(context.Background(), &greetpb.GreetRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Greet returned %v without credentials, want Unauthenticated", err)
+	}
+}
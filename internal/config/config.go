@@ -0,0 +1,525 @@
+// Package config holds the settings shared by the greet and calculator server
+// and client binaries and the REST gateway. Values are read from a YAML or JSON file, environment
+// variables and command line flags, later sources overriding earlier ones:
+// flags win over environment variables, which win over the file.
+package config
+
+import (
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Role tells Load which binary the configuration is for, so that only the
+// relevant settings become flags and get validated. The role tag of a field
+// lists the roles it applies to, separated by commas; untagged fields apply
+// to every role.
+type Role int
+
+const (
+	Server Role = iota
+	Client
+	// Gateway listens like a server but dials the servers like a client.
+	Gateway
+)
+
+// maxMessageSize caps the configurable message sizes at 1GiB.
+const maxMessageSize = 1 << 30
+
+// Config is the complete configuration of a server or client binary.
+type Config struct {
+	// ListenAddr is the address the server accepts connections on.
+	ListenAddr string `yaml:"listen_addr" config:"listen" role:"server,gateway" usage:"address the server listens on"`
+	// Target is the address the client dials.
+	Target string `yaml:"target" config:"target" role:"client" usage:"address of the server to dial"`
+
+	MaxRecvMsgSize int           `yaml:"max_recv_msg_size" config:"max-recv-msg-size" usage:"largest message in bytes that may be received, 0 keeps the gRPC default"`
+	MaxSendMsgSize int           `yaml:"max_send_msg_size" config:"max-send-msg-size" usage:"largest message in bytes that may be sent, 0 keeps the gRPC default"`
+	DrainTimeout   time.Duration `yaml:"drain_timeout" config:"drain-timeout" role:"server,gateway" usage:"how long to wait for in-flight calls on shutdown before stopping hard"`
+
+	Keepalive Keepalive `yaml:"keepalive" config:"keepalive"`
+	TLS       TLS       `yaml:"tls" config:"tls"`
+	Auth      Auth      `yaml:"auth" config:"auth"`
+	Authz     Authz     `yaml:"authz" config:"authz" role:"server"`
+
+	// LogLevel is one of debug, info, warn or error.
+	LogLevel string `yaml:"log_level" config:"log-level" usage:"minimum level of log lines: debug, info, warn or error"`
+	// LogPayloads adds request messages to the call logs, LogRedact blanks out their string fields.
+	LogPayloads bool `yaml:"log_payloads" config:"log-payloads" role:"server" usage:"include request payloads in call logs"`
+	LogRedact   bool `yaml:"log_redact" config:"log-redact" role:"server" usage:"replace string and bytes fields of logged payloads with [REDACTED]"`
+
+	Metrics  Metrics  `yaml:"metrics" config:"metrics" role:"server"`
+	GRPCWeb  GRPCWeb  `yaml:"grpc_web" config:"grpc-web" role:"server"`
+	Batch    Batch    `yaml:"batch" config:"batch" role:"server"`
+	Upstream Upstream `yaml:"upstream" config:"" role:"gateway"`
+	Tracing  Tracing  `yaml:"tracing" config:"tracing"`
+	Features Features `yaml:"features" config:""`
+}
+
+// Keepalive configures HTTP/2 keepalive pings. The server enforces MinTime and
+// PermitWithoutStream on its clients.
+type Keepalive struct {
+	Time                time.Duration `yaml:"time" config:"time" usage:"ping the peer after this much inactivity, 0 disables pings"`
+	Timeout             time.Duration `yaml:"timeout" config:"timeout" usage:"close the connection when a ping is not answered within this time"`
+	MinTime             time.Duration `yaml:"min_time" config:"min-time" role:"server" usage:"minimum interval between client pings the server tolerates"`
+	PermitWithoutStream bool          `yaml:"permit_without_stream" config:"permit-without-stream" usage:"allow pings while there is no active stream"`
+	MaxConnectionIdle   time.Duration `yaml:"max_connection_idle" config:"max-connection-idle" role:"server" usage:"close connections that have been idle this long, 0 means never"`
+	MaxConnectionAge    time.Duration `yaml:"max_connection_age" config:"max-connection-age" role:"server" usage:"gracefully close connections after this long, 0 means never"`
+}
+
+// TLS points at the certificates used for transport security. On the server
+// CertFile/KeyFile are the serving certificate and CAFile verifies client
+// certificates; on the client CertFile/KeyFile are the optional client
+// certificate and CAFile verifies the server.
+type TLS struct {
+	Enabled    bool   `yaml:"enabled" config:"enabled" usage:"use TLS for the connection"`
+	CertFile   string `yaml:"cert_file" config:"cert" usage:"PEM certificate file"`
+	KeyFile    string `yaml:"key_file" config:"key" usage:"PEM private key file"`
+	CAFile     string `yaml:"ca_file" config:"ca" usage:"PEM CA bundle used to verify the peer"`
+	ClientAuth bool   `yaml:"client_auth" config:"client-auth" role:"server" usage:"require and verify client certificates (mutual TLS)"`
+	ServerName string `yaml:"server_name" config:"server-name" role:"client,gateway" usage:"override the name expected in the server certificate"`
+	// ReloadInterval is how often the server checks CertFile/KeyFile for a rotated pair.
+	ReloadInterval time.Duration `yaml:"reload_interval" config:"reload-interval" role:"server" usage:"how often to check the certificate files for changes, 0 disables reloading"`
+}
+
+// Auth configures per-call authentication. The server accepts the API keys and
+// JWTs described here; the client attaches Token (or the content of TokenFile)
+// to every call. The gateway has no credentials of its own and only forwards
+// those of its callers.
+type Auth struct {
+	Enabled bool `yaml:"enabled" config:"enabled" role:"server" usage:"reject calls without valid credentials"`
+	// APIKeys can only be set in the configuration file.
+	APIKeys []APIKey `yaml:"api_keys" role:"server"`
+	JWT     JWT      `yaml:"jwt" config:"jwt" role:"server"`
+	// PublicMethods are full method names or prefixes ending in / that need no credentials.
+	PublicMethods []string `yaml:"public_methods" config:"public-methods" role:"server" usage:"comma separated methods or service prefixes that need no credentials"`
+
+	Token         string `yaml:"token" config:"token" role:"client" usage:"API key or JWT sent with every call"`
+	TokenFile     string `yaml:"token_file" config:"token-file" role:"client" usage:"file holding the API key or JWT sent with every call"`
+	AllowInsecure bool   `yaml:"allow_insecure" config:"allow-insecure" role:"client" usage:"allow sending the token over a connection without TLS (development only)"`
+}
+
+// APIKey is a static key and the identity it stands for.
+type APIKey struct {
+	Key     string   `yaml:"key"`
+	Subject string   `yaml:"subject"`
+	Roles   []string `yaml:"roles"`
+}
+
+// JWT configures which bearer tokens the server trusts. HS256 tokens are
+// verified with the shared secret, RS256 tokens with the public key.
+type JWT struct {
+	HMACSecretFile   string `yaml:"hmac_secret_file" config:"hmac-secret-file" usage:"file holding the HS256 shared secret"`
+	RSAPublicKeyFile string `yaml:"rsa_public_key_file" config:"rsa-public-key-file" usage:"PEM file holding the RS256 public key"`
+	Issuer           string `yaml:"issuer" config:"issuer" usage:"required iss claim, empty accepts any"`
+	Audience         string `yaml:"audience" config:"audience" usage:"required aud claim, empty accepts any"`
+	RolesClaim       string `yaml:"roles_claim" config:"roles-claim" usage:"claim that lists the roles of the caller"`
+}
+
+// Authz points at the policy that decides which callers may use which methods.
+type Authz struct {
+	PolicyFile string `yaml:"policy_file" config:"policy-file" usage:"YAML policy mapping subjects and roles to methods, empty disables authorization"`
+}
+
+// Metrics configures the Prometheus endpoint.
+type Metrics struct {
+	Listen string `yaml:"listen" config:"listen" usage:"address of the HTTP listener serving /metrics, empty to disable"`
+}
+
+func (m Metrics) validate(listenAddr string) error {
+	if m.Listen == "" {
+		return nil
+	}
+	if err := validateAddr("metrics-listen", m.Listen); err != nil {
+		return err
+	}
+	if m.Listen == listenAddr {
+		return fmt.Errorf("metrics-listen must differ from listen, both are %q", m.Listen)
+	}
+
+	return nil
+}
+
+// GRPCWeb configures the listener serving the services to browsers through gRPC-Web.
+type GRPCWeb struct {
+	Listen         string   `yaml:"listen" config:"listen" usage:"address of the gRPC-Web listener (HTTP/1.1 and HTTP/2, TLS as configured for gRPC), empty to disable"`
+	AllowedOrigins []string `yaml:"allowed_origins" config:"allowed-origins" usage:"comma separated origins browsers may call the gRPC-Web listener from, * for any"`
+}
+
+func (w GRPCWeb) validate(listenAddr string, metricsAddr string) error {
+	if w.Listen == "" {
+		return nil
+	}
+	if err := validateAddr("grpc-web-listen", w.Listen); err != nil {
+		return err
+	}
+	if w.Listen == listenAddr || w.Listen == metricsAddr {
+		return fmt.Errorf("grpc-web-listen must differ from listen and metrics-listen, got %q", w.Listen)
+	}
+
+	return nil
+}
+
+// Batch bounds the work of a single BatchCalculate call.
+type Batch struct {
+	Concurrency      int           `yaml:"concurrency" config:"concurrency" usage:"operations of a BatchCalculate call processed in parallel"`
+	MaxOperations    int           `yaml:"max_operations" config:"max-operations" usage:"most operations accepted in a single BatchCalculate request"`
+	OperationTimeout time.Duration `yaml:"operation_timeout" config:"operation-timeout" usage:"how long a single batch operation may run before it fails with DeadlineExceeded, 0 means no limit"`
+}
+
+func (b Batch) validate() error {
+	if b.Concurrency < 1 {
+		return fmt.Errorf("batch-concurrency must be at least 1, got %d", b.Concurrency)
+	}
+	if b.MaxOperations < 1 {
+		return fmt.Errorf("batch-max-operations must be at least 1, got %d", b.MaxOperations)
+	}
+	if b.OperationTimeout < 0 {
+		return fmt.Errorf("batch-operation-timeout must not be negative, got %v", b.OperationTimeout)
+	}
+
+	return nil
+}
+
+// Upstream names the servers the gateway forwards to.
+type Upstream struct {
+	GreetTarget      string `yaml:"greet_target" config:"greet-target" usage:"address of the greet server"`
+	CalculatorTarget string `yaml:"calculator_target" config:"calculator-target" usage:"address of the calculator server"`
+}
+
+// Tracing selects where OpenTelemetry spans are exported to.
+type Tracing struct {
+	Exporter string `yaml:"exporter" config:"exporter" usage:"span exporter: none, stdout or otlp-file"`
+	File     string `yaml:"file" config:"file" usage:"file the otlp-file exporter appends OTLP/JSON lines to"`
+}
+
+func (t Tracing) validate() error {
+	switch t.Exporter {
+	case "none", "stdout":
+	case "otlp-file":
+		if t.File == "" {
+			return fmt.Errorf("tracing-file is required by the otlp-file exporter")
+		}
+	default:
+		return fmt.Errorf("tracing-exporter must be one of none, stdout or otlp-file, got %q", t.Exporter)
+	}
+
+	return nil
+}
+
+// Features switches optional parts of the servers on or off.
+type Features struct {
+	Reflection bool `yaml:"reflection" config:"reflection" role:"server" usage:"expose the gRPC server reflection service for grpcurl and similar tools"`
+	Health     bool `yaml:"health" config:"health" role:"server" usage:"expose the grpc.health.v1.Health service"`
+}
+
+// ServerDefaults returns the defaults of a server listening on listenAddr.
+func ServerDefaults(listenAddr string) Config {
+	return Config{
+		ListenAddr:   listenAddr,
+		DrainTimeout: 30 * time.Second,
+		Keepalive: Keepalive{
+			Timeout: 20 * time.Second,
+			MinTime: 5 * time.Minute,
+		},
+		TLS: TLS{
+			ReloadInterval: 30 * time.Second,
+		},
+		Auth: Auth{
+			JWT: JWT{
+				RolesClaim: "roles",
+			},
+			PublicMethods: []string{
+				"/grpc.health.v1.Health/",
+				"/grpc.reflection.v1.ServerReflection/",
+				"/grpc.reflection.v1alpha.ServerReflection/",
+			},
+		},
+		LogLevel:  "info",
+		LogRedact: true,
+		Batch: Batch{
+			Concurrency:      8,
+			MaxOperations:    10000,
+			OperationTimeout: 10 * time.Second,
+		},
+		Tracing: Tracing{
+			Exporter: "none",
+		},
+		Features: Features{
+			Health: true,
+		},
+	}
+}
+
+// ClientDefaults returns the defaults of a client dialing target.
+func ClientDefaults(target string) Config {
+	return Config{
+		Target: target,
+		Keepalive: Keepalive{
+			Timeout: 20 * time.Second,
+		},
+		LogLevel: "info",
+		Tracing: Tracing{
+			Exporter: "none",
+		},
+	}
+}
+
+// GatewayDefaults returns the defaults of a gateway listening on listenAddr
+// and forwarding to the servers on their default ports.
+func GatewayDefaults(listenAddr string) Config {
+	cfg := ClientDefaults("")
+	cfg.ListenAddr = listenAddr
+	cfg.DrainTimeout = 30 * time.Second
+	cfg.Upstream = Upstream{
+		GreetTarget:      "localhost:50051",
+		CalculatorTarget: "localhost:50052",
+	}
+
+	return cfg
+}
+
+// Validate reports the first setting that is unusable for role.
+func (c *Config) Validate(role Role) error {
+	switch role {
+	case Server:
+		if err := validateAddr("listen", c.ListenAddr); err != nil {
+			return err
+		}
+		if c.DrainTimeout <= 0 {
+			return fmt.Errorf("drain-timeout must be positive, got %v", c.DrainTimeout)
+		}
+		if err := c.Metrics.validate(c.ListenAddr); err != nil {
+			return err
+		}
+		if err := c.GRPCWeb.validate(c.ListenAddr, c.Metrics.Listen); err != nil {
+			return err
+		}
+		if err := c.Batch.validate(); err != nil {
+			return err
+		}
+	case Client:
+		if strings.TrimSpace(c.Target) == "" {
+			return fmt.Errorf("target must not be empty")
+		}
+	case Gateway:
+		if err := validateAddr("listen", c.ListenAddr); err != nil {
+			return err
+		}
+		if c.DrainTimeout <= 0 {
+			return fmt.Errorf("drain-timeout must be positive, got %v", c.DrainTimeout)
+		}
+		if strings.TrimSpace(c.Upstream.GreetTarget) == "" || strings.TrimSpace(c.Upstream.CalculatorTarget) == "" {
+			return fmt.Errorf("greet-target and calculator-target must not be empty")
+		}
+	}
+
+	if c.MaxRecvMsgSize < 0 || c.MaxRecvMsgSize > maxMessageSize {
+		return fmt.Errorf("max-recv-msg-size must be between 0 and %d, got %d", maxMessageSize, c.MaxRecvMsgSize)
+	}
+	if c.MaxSendMsgSize < 0 || c.MaxSendMsgSize > maxMessageSize {
+		return fmt.Errorf("max-send-msg-size must be between 0 and %d, got %d", maxMessageSize, c.MaxSendMsgSize)
+	}
+
+	if err := c.Keepalive.validate(); err != nil {
+		return err
+	}
+	if err := c.TLS.validate(role); err != nil {
+		return err
+	}
+	if err := c.Auth.validate(role, c.TLS.Enabled); err != nil {
+		return err
+	}
+	if err := c.Tracing.validate(); err != nil {
+		return err
+	}
+	if role == Server && c.Authz.PolicyFile != "" {
+		if !c.Auth.Enabled {
+			return fmt.Errorf("authz-policy-file requires auth-enabled")
+		}
+		if _, err := os.Stat(c.Authz.PolicyFile); err != nil {
+			return fmt.Errorf("authz-policy-file: %w", err)
+		}
+	}
+
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("log-level must be one of debug, info, warn or error, got %q", c.LogLevel)
+	}
+
+	return nil
+}
+
+func (k *Keepalive) validate() error {
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"keepalive-time", k.Time},
+		{"keepalive-timeout", k.Timeout},
+		{"keepalive-min-time", k.MinTime},
+		{"keepalive-max-connection-idle", k.MaxConnectionIdle},
+		{"keepalive-max-connection-age", k.MaxConnectionAge},
+	}
+	for _, d := range durations {
+		if d.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %v", d.name, d.value)
+		}
+	}
+	if k.Time > 0 && k.Timeout == 0 {
+		return fmt.Errorf("keepalive-timeout must be set when keepalive-time is")
+	}
+
+	return nil
+}
+
+func (t *TLS) validate(role Role) error {
+	if t.ReloadInterval < 0 {
+		return fmt.Errorf("tls-reload-interval must not be negative, got %v", t.ReloadInterval)
+	}
+	if !t.Enabled {
+		if t.ClientAuth {
+			return fmt.Errorf("tls-client-auth requires tls-enabled")
+		}
+		return nil
+	}
+
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return fmt.Errorf("tls-cert and tls-key must be set together")
+	}
+	if role == Server && t.CertFile == "" {
+		return fmt.Errorf("tls-cert and tls-key are required when tls-enabled is set")
+	}
+	if role == Server && t.ClientAuth && t.CAFile == "" {
+		return fmt.Errorf("tls-ca is required to verify client certificates")
+	}
+
+	for name, path := range map[string]string{"tls-cert": t.CertFile, "tls-key": t.KeyFile, "tls-ca": t.CAFile} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Auth) validate(role Role, tlsEnabled bool) error {
+	if role == Client {
+		if a.Token != "" && a.TokenFile != "" {
+			return fmt.Errorf("auth-token and auth-token-file are mutually exclusive")
+		}
+		if a.TokenFile != "" {
+			if _, err := os.Stat(a.TokenFile); err != nil {
+				return fmt.Errorf("auth-token-file: %w", err)
+			}
+		}
+		if (a.Token != "" || a.TokenFile != "") && !tlsEnabled && !a.AllowInsecure {
+			return fmt.Errorf("sending a token requires tls-enabled or auth-allow-insecure")
+		}
+		return nil
+	}
+
+	if !a.Enabled {
+		return nil
+	}
+	if len(a.APIKeys) == 0 && a.JWT.HMACSecretFile == "" && a.JWT.RSAPublicKeyFile == "" {
+		return fmt.Errorf("auth-enabled needs api_keys in the config file or a JWT key")
+	}
+
+	seen := make(map[string]bool, len(a.APIKeys))
+	for i, key := range a.APIKeys {
+		if key.Key == "" || key.Subject == "" {
+			return fmt.Errorf("auth api_keys[%d] needs both key and subject", i)
+		}
+		if seen[key.Key] {
+			return fmt.Errorf("auth api_keys[%d] (%s) reuses the key of another entry", i, key.Subject)
+		}
+		seen[key.Key] = true
+	}
+
+	for name, path := range map[string]string{"auth-jwt-hmac-secret-file": a.JWT.HMACSecretFile, "auth-jwt-rsa-public-key-file": a.JWT.RSAPublicKeyFile} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if a.JWT.RolesClaim == "" {
+		return fmt.Errorf("auth-jwt-roles-claim must not be empty")
+	}
+
+	return nil
+}
+
+func validateAddr(name string, addr string) error {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("%s must be host:port, got %q: %w", name, addr, err)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		return fmt.Errorf("%s has an invalid port %q", name, port)
+	}
+
+	return nil
+}
+
+// ServerOptions translates the message size and keepalive settings into grpc.ServerOptions.
+func (c *Config) ServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if c.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(c.MaxRecvMsgSize))
+	}
+	if c.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(c.MaxSendMsgSize))
+	}
+
+	opts = append(opts,
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              c.Keepalive.Time,
+			Timeout:           c.Keepalive.Timeout,
+			MaxConnectionIdle: c.Keepalive.MaxConnectionIdle,
+			MaxConnectionAge:  c.Keepalive.MaxConnectionAge,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             c.Keepalive.MinTime,
+			PermitWithoutStream: c.Keepalive.PermitWithoutStream,
+		}),
+	)
+
+	return opts
+}
+
+// DialOptions translates the message size and keepalive settings into grpc.DialOptions.
+func (c *Config) DialOptions() []grpc.DialOption {
+	var callOpts []grpc.CallOption
+	if c.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(c.MaxRecvMsgSize))
+	}
+	if c.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(c.MaxSendMsgSize))
+	}
+
+	var opts []grpc.DialOption
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	if c.Keepalive.Time > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.Keepalive.Time,
+			Timeout:             c.Keepalive.Timeout,
+			PermitWithoutStream: c.Keepalive.PermitWithoutStream,
+		}))
+	}
+
+	return opts
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	existing := writeFile(t, "existing", "content")
+	const missing = "/does/not/exist"
+
+	tests := []struct {
+		name   string
+		role   Role
+		change func(c *Config)
+		// want is part of the error, empty when the configuration is valid
+		want string
+	}{
+		{"server defaults", Server, func(c *Config) {}, ""},
+		{"client defaults", Client, func(c *Config) {}, ""},
+		{"gateway defaults", Gateway, func(c *Config) {}, ""},
+
+		{"listen without port", Server, func(c *Config) { c.ListenAddr = "localhost" }, "listen must be host:port"},
+		{"listen port out of range", Server, func(c *Config) { c.ListenAddr = "localhost:65536" }, "listen has an invalid port"},
+		{"server drain timeout", Server, func(c *Config) { c.DrainTimeout = 0 }, "drain-timeout must be positive"},
+		{"metrics address", Server, func(c *Config) { c.Metrics.Listen = "9090" }, "metrics-listen must be host:port"},
+		{"metrics on the gRPC port", Server, func(c *Config) { c.Metrics.Listen = c.ListenAddr }, "metrics-listen must differ from listen"},
+		{"gRPC-Web address", Server, func(c *Config) { c.GRPCWeb.Listen = "localhost:http" }, "grpc-web-listen has an invalid port"},
+		{"gRPC-Web on the metrics port", Server, func(c *Config) {
+			c.Metrics.Listen = "localhost:9090"
+			c.GRPCWeb.Listen = "localhost:9090"
+		}, "grpc-web-listen must differ"},
+		{"batch concurrency", Server, func(c *Config) { c.Batch.Concurrency = 0 }, "batch-concurrency must be at least 1"},
+		{"batch size", Server, func(c *Config) { c.Batch.MaxOperations = 0 }, "batch-max-operations must be at least 1"},
+		{"batch timeout", Server, func(c *Config) { c.Batch.OperationTimeout = -time.Second }, "batch-operation-timeout must not be negative"},
+
+		{"empty target", Client, func(c *Config) { c.Target = " " }, "target must not be empty"},
+
+		{"gateway listen", Gateway, func(c *Config) { c.ListenAddr = "" }, "listen must be host:port"},
+		{"gateway drain timeout", Gateway, func(c *Config) { c.DrainTimeout = -time.Second }, "drain-timeout must be positive"},
+		{"gateway upstream", Gateway, func(c *Config) { c.Upstream.CalculatorTarget = "" }, "greet-target and calculator-target must not be empty"},
+
+		{"negative receive size", Client, func(c *Config) { c.MaxRecvMsgSize = -1 }, "max-recv-msg-size must be between"},
+		{"huge send size", Server, func(c *Config) { c.MaxSendMsgSize = maxMessageSize + 1 }, "max-send-msg-size must be between"},
+
+		{"negative keepalive", Server, func(c *Config) { c.Keepalive.MaxConnectionAge = -time.Second }, "keepalive-max-connection-age must not be negative"},
+		{"keepalive without timeout", Client, func(c *Config) {
+			c.Keepalive.Time = time.Minute
+			c.Keepalive.Timeout = 0
+		}, "keepalive-timeout must be set"},
+
+		{"negative reload interval", Server, func(c *Config) { c.TLS.ReloadInterval = -time.Second }, "tls-reload-interval must not be negative"},
+		{"client auth without TLS", Server, func(c *Config) { c.TLS.ClientAuth = true }, "tls-client-auth requires tls-enabled"},
+		{"certificate without key", Client, func(c *Config) {
+			c.TLS = TLS{Enabled: true, CertFile: existing}
+		}, "tls-cert and tls-key must be set together"},
+		{"server TLS without certificate", Server, func(c *Config) { c.TLS.Enabled = true }, "tls-cert and tls-key are required"},
+		{"client auth without CA", Server, func(c *Config) {
+			c.TLS = TLS{Enabled: true, CertFile: existing, KeyFile: existing, ClientAuth: true}
+		}, "tls-ca is required"},
+		{"missing CA", Client, func(c *Config) { c.TLS = TLS{Enabled: true, CAFile: missing} }, "tls-ca: "},
+		{"client TLS with the system roots", Client, func(c *Config) { c.TLS = TLS{Enabled: true} }, ""},
+
+		{"token and token file", Client, func(c *Config) {
+			c.Auth.Token = "secret"
+			c.Auth.TokenFile = existing
+		}, "mutually exclusive"},
+		{"missing token file", Client, func(c *Config) { c.Auth = Auth{TokenFile: missing, AllowInsecure: true} }, "auth-token-file: "},
+		{"token in cleartext", Client, func(c *Config) { c.Auth.Token = "secret" }, "sending a token requires tls-enabled or auth-allow-insecure"},
+		{"token in cleartext allowed", Client, func(c *Config) { c.Auth = Auth{Token: "secret", AllowInsecure: true} }, ""},
+
+		{"auth without credentials", Server, func(c *Config) { c.Auth.Enabled = true }, "auth-enabled needs api_keys"},
+		{"API key without subject", Server, func(c *Config) {
+			c.Auth.Enabled = true
+			c.Auth.APIKeys = []APIKey{{Key: "k"}}
+		}, "api_keys[0] needs both key and subject"},
+		{"reused API key", Server, func(c *Config) {
+			c.Auth.Enabled = true
+			c.Auth.APIKeys = []APIKey{{Key: "k", Subject: "a"}, {Key: "k", Subject: "b"}}
+		}, "api_keys[1] (b) reuses the key"},
+		{"missing JWT key", Server, func(c *Config) {
+			c.Auth.Enabled = true
+			c.Auth.JWT.RSAPublicKeyFile = missing
+		}, "auth-jwt-rsa-public-key-file: "},
+		{"empty roles claim", Server, func(c *Config) {
+			c.Auth.Enabled = true
+			c.Auth.JWT.HMACSecretFile = existing
+			c.Auth.JWT.RolesClaim = ""
+		}, "auth-jwt-roles-claim must not be empty"},
+
+		{"unknown exporter", Client, func(c *Config) { c.Tracing.Exporter = "jaeger" }, "tracing-exporter must be one of"},
+		{"otlp-file without file", Server, func(c *Config) { c.Tracing.Exporter = "otlp-file" }, "tracing-file is required"},
+
+		{"policy without authentication", Server, func(c *Config) { c.Authz.PolicyFile = existing }, "authz-policy-file requires auth-enabled"},
+		{"missing policy", Server, func(c *Config) {
+			c.Auth.Enabled = true
+			c.Auth.APIKeys = []APIKey{{Key: "k", Subject: "a"}}
+			c.Authz.PolicyFile = missing
+		}, "authz-policy-file: "},
+
+		{"unknown log level", Gateway, func(c *Config) { c.LogLevel = "trace" }, "log-level must be one of"},
+	}
+
+	for _, tt := range tests {
+		cfg := defaults(tt.role)
+		tt.change(&cfg)
+
+		err := cfg.Validate(tt.role)
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("%s: Validate returned %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate returned %v, want an error containing %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Options tells Load where to look for settings.
+type Options struct {
+	// Role selects the server, client or gateway settings.
+	Role Role
+	// EnvPrefix is prepended to every environment variable, e.g. CALCULATOR
+	// makes the listen address CALCULATOR_LISTEN and the file CALCULATOR_CONFIG.
+	EnvPrefix string
+	// Defaults are used for everything no source sets.
+	Defaults Config
+}
+
+// setting is a single leaf of Config that can be set from a string.
+type setting struct {
+	name  string // flag name, e.g. keepalive-time
+	env   string // environment variable without the prefix, e.g. KEEPALIVE_TIME
+	usage string
+	value reflect.Value
+}
+
+// flagValue records what was passed on the command line; it is applied only
+// after the file and the environment so that flags take precedence.
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *flagValue) Set(s string) error {
+	f.value = s
+	return nil
+}
+
+// IsBoolFlag lets boolean settings be passed as a plain -flag.
+func (f *flagValue) IsBoolFlag() bool { return f.isBool }
+
+// Load registers the settings relevant to opts.Role on fs, parses args and
+// returns the validated configuration. Callers may register flags of their
+// own on fs before calling Load.
+func Load(fs *flag.FlagSet, args []string, opts Options) (*Config, error) {
+	cfg := opts.Defaults
+	settings := collect(reflect.ValueOf(&cfg).Elem(), "", opts.Role)
+
+	configFile := fs.String("config", "", fmt.Sprintf("YAML or JSON configuration file (env %s)", envName(opts.EnvPrefix, "CONFIG")))
+	for _, s := range settings {
+		fv := &flagValue{value: formatValue(s.value), isBool: s.value.Kind() == reflect.Bool}
+		fs.Var(fv, s.name, fmt.Sprintf("%s (env %s)", s.usage, envName(opts.EnvPrefix, s.env)))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// 1. file
+	path := *configFile
+	if path == "" {
+		path = os.Getenv(envName(opts.EnvPrefix, "CONFIG"))
+	}
+	if path != "" {
+		if err := readFile(path, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. environment
+	for _, s := range settings {
+		name := envName(opts.EnvPrefix, s.env)
+		if v, ok := os.LookupEnv(name); ok {
+			if err := setValue(s.value, v); err != nil {
+				return nil, fmt.Errorf("invalid value %q for %s: %w", v, name, err)
+			}
+		}
+	}
+
+	// 3. flags
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		if flagErr != nil || f.Name == "config" {
+			return
+		}
+		s, ok := find(settings, f.Name)
+		if !ok {
+			return
+		}
+		v := f.Value.String()
+		if err := setValue(s.value, v); err != nil {
+			flagErr = fmt.Errorf("invalid value %q for -%s: %w", v, f.Name, err)
+		}
+	})
+	if flagErr != nil {
+		return nil, flagErr
+	}
+
+	if err := cfg.Validate(opts.Role); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+func readFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	// JSON is a subset of YAML, so one decoder serves both formats
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// collect walks the struct v and returns every leaf that is relevant to role.
+func collect(v reflect.Value, prefix string, role Role) []setting {
+	var settings []setting
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := field.Tag.Lookup("config")
+		if !ok {
+			continue
+		}
+		if r := field.Tag.Get("role"); r != "" && !hasRole(r, role) {
+			continue
+		}
+		if prefix != "" && name != "" {
+			name = prefix + "-" + name
+		} else if name == "" {
+			name = prefix
+		}
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+			settings = append(settings, collect(v.Field(i), name, role)...)
+			continue
+		}
+
+		settings = append(settings, setting{
+			name:  name,
+			env:   strings.ToUpper(strings.ReplaceAll(name, "-", "_")),
+			usage: field.Tag.Get("usage"),
+			value: v.Field(i),
+		})
+	}
+
+	return settings
+}
+
+func find(settings []setting, name string) (setting, bool) {
+	for _, s := range settings {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func setValue(v reflect.Value, s string) error {
+	switch {
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(s)
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %v", v.Type())
+	}
+
+	return nil
+}
+
+func formatValue(v reflect.Value) string {
+	switch {
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		return time.Duration(v.Int()).String()
+	case v.Kind() == reflect.Slice:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).String()
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func envName(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+func roleName(role Role) string {
+	switch role {
+	case Client:
+		return "client"
+	case Gateway:
+		return "gateway"
+	default:
+		return "server"
+	}
+}
+
+// hasRole reports whether the comma separated roles of a tag include role.
+func hasRole(tag string, role Role) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if strings.TrimSpace(r) == roleName(role) {
+			return true
+		}
+	}
+	return false
+}
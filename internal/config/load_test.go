@@ -0,0 +1,190 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// defaults returns the defaults the binaries of role start from.
+func defaults(role Role) Config {
+	switch role {
+	case Client:
+		return ClientDefaults("localhost:50051")
+	case Gateway:
+		return GatewayDefaults("localhost:8080")
+	default:
+		return ServerDefaults("localhost:50051")
+	}
+}
+
+// load runs Load for role with the TEST environment prefix and the defaults of
+// the role.
+func load(role Role, args ...string) (*Config, error) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	return Load(fs, args, Options{Role: role, EnvPrefix: "TEST", Defaults: defaults(role)})
+}
+
+// writeFile writes content to a fresh file and returns its path.
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+drain_timeout: 10s
+keepalive:
+  time: 1m
+grpc_web:
+  allowed_origins: [https://file.example]
+features:
+  reflection: true
+`)
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		args  []string
+		check func(*Config) bool
+	}{
+		{"defaults", nil, nil, func(c *Config) bool {
+			return c.DrainTimeout == 30*time.Second && !c.Features.Reflection && c.Features.Health
+		}},
+		{"file", nil, []string{"-config", file}, func(c *Config) bool {
+			return c.DrainTimeout == 10*time.Second && c.Keepalive.Time == time.Minute && c.Features.Reflection &&
+				strings.Join(c.GRPCWeb.AllowedOrigins, " ") == "https://file.example"
+		}},
+		{"file named by the environment", map[string]string{"TEST_CONFIG": file}, nil, func(c *Config) bool {
+			return c.DrainTimeout == 10*time.Second
+		}},
+		{"environment over file", map[string]string{
+			"TEST_DRAIN_TIMEOUT":            "20s",
+			"TEST_REFLECTION":               "false",
+			"TEST_GRPC_WEB_ALLOWED_ORIGINS": "https://a.example, https://b.example",
+		}, []string{"-config", file}, func(c *Config) bool {
+			return c.DrainTimeout == 20*time.Second && c.Keepalive.Time == time.Minute && !c.Features.Reflection &&
+				strings.Join(c.GRPCWeb.AllowedOrigins, " ") == "https://a.example https://b.example"
+		}},
+		{"flags over environment", map[string]string{"TEST_DRAIN_TIMEOUT": "20s", "TEST_REFLECTION": "false"},
+			[]string{"-config", file, "-drain-timeout", "40s", "-reflection", "-keepalive-time", "2m"}, func(c *Config) bool {
+				return c.DrainTimeout == 40*time.Second && c.Keepalive.Time == 2*time.Minute && c.Features.Reflection
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			cfg, err := load(Server, tt.args...)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if !tt.check(cfg) {
+				t.Errorf("unexpected configuration %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsBadValues(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  map[string]string
+		args []string
+		want string
+	}{
+		{"unknown file key", "listen_address: localhost:1\n", nil, nil, "field listen_address not found"},
+		{"bad file value", "drain_timeout: soon\n", nil, nil, "parsing config file"},
+		{"malformed file", "drain_timeout: [\n", nil, nil, "parsing config file"},
+		{"bad environment value", "", map[string]string{"TEST_DRAIN_TIMEOUT": "soon"}, nil, `invalid value "soon" for TEST_DRAIN_TIMEOUT`},
+		{"bad boolean in the environment", "", map[string]string{"TEST_REFLECTION": "maybe"}, nil, "for TEST_REFLECTION"},
+		{"bad number in the environment", "", map[string]string{"TEST_BATCH_CONCURRENCY": "many"}, nil, "for TEST_BATCH_CONCURRENCY"},
+		{"bad flag value", "", nil, []string{"-max-recv-msg-size", "big"}, `invalid value "big" for -max-recv-msg-size`},
+		{"unknown flag", "", nil, []string{"-listen-address", "localhost:1"}, "flag provided but not defined"},
+		{"missing file", "", nil, []string{"-config", "does-not-exist.yaml"}, "reading config file"},
+		{"invalid result", "drain_timeout: 0s\n", nil, nil, "invalid configuration: drain-timeout must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeFile(t, "config.yaml", tt.file)}, args...)
+			}
+
+			_, err := load(Server, args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load returned %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFiltersSettingsByRole(t *testing.T) {
+	tests := []struct {
+		name string
+		role Role
+		args []string
+		// ok tells whether the flags exist for the role
+		ok bool
+	}{
+		{"server listens", Server, []string{"-listen", "localhost:1"}, true},
+		{"server does not dial", Server, []string{"-target", "localhost:1"}, false},
+		{"server has no token", Server, []string{"-auth-token", "secret"}, false},
+		{"client dials", Client, []string{"-target", "localhost:1"}, true},
+		{"client does not listen", Client, []string{"-listen", "localhost:1"}, false},
+		{"client does not drain", Client, []string{"-drain-timeout", "1s"}, false},
+		{"client has no reflection", Client, []string{"-reflection"}, false},
+		{"client has no metrics", Client, []string{"-metrics-listen", "localhost:9090"}, false},
+		{"client has no authorization", Client, []string{"-authz-policy-file", "policy.yaml"}, false},
+		{"client has no server TLS settings", Client, []string{"-tls-client-auth"}, false},
+		{"shared settings", Client, []string{"-keepalive-time", "1m", "-log-level", "debug"}, true},
+		{"gateway listens", Gateway, []string{"-listen", "localhost:1", "-greet-target", "localhost:2"}, true},
+		{"gateway has no batch settings", Gateway, []string{"-batch-concurrency", "2"}, false},
+		{"gateway has no token", Gateway, []string{"-auth-token", "secret"}, false},
+		{"server has no upstream", Server, []string{"-calculator-target", "localhost:2"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(tt.role, tt.args...)
+			if tt.ok && err != nil {
+				t.Errorf("Load failed: %v", err)
+			}
+			if !tt.ok && (err == nil || !strings.Contains(err.Error(), "flag provided but not defined")) {
+				t.Errorf("Load returned %v, want the flag to be undefined", err)
+			}
+		})
+	}
+}
+
+func TestLoadIgnoresTheEnvironmentOfOtherRoles(t *testing.T) {
+	t.Setenv("TEST_LISTEN", "not an address")
+	t.Setenv("TEST_DRAIN_TIMEOUT", "soon")
+
+	cfg, err := load(Client)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Target != "localhost:50051" || cfg.ListenAddr != "" {
+		t.Errorf("Load read settings of another role: %+v", cfg)
+	}
+}
@@ -0,0 +1,172 @@
+package grpcweb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	contentType = "application/grpc-web+proto"
+	// trailerFlag marks the frame that carries the trailers instead of a message.
+	trailerFlag = 0x80
+)
+
+// Client calls methods over gRPC-Web the way a browser does, for tests and
+// smoke checks of the gRPC-Web listener. Only unary and server-streaming
+// methods can be called.
+type Client struct {
+	// HTTPClient sends the requests, http.DefaultClient when nil. Its
+	// transport decides between HTTP/1.1 and HTTP/2.
+	HTTPClient *http.Client
+	// BaseURL is the scheme and host of the listener, e.g. http://localhost:8081.
+	BaseURL string
+	// Header is added to every request, e.g. Origin or Authorization.
+	Header http.Header
+}
+
+// Invoke calls a unary method, method being the full name "/package.Service/Method".
+func (c *Client) Invoke(ctx context.Context, method string, req proto.Message, resp proto.Message) error {
+	stream, err := c.NewStream(ctx, method, req)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	if err := stream.Recv(resp); err != nil {
+		if err == io.EOF {
+			return status.Error(codes.Internal, "no response message")
+		}
+		return err
+	}
+	if err := stream.Recv(proto.Clone(resp)); err != io.EOF {
+		if err == nil {
+			return status.Error(codes.Internal, "more than one response message")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// NewStream sends req and returns the stream of responses.
+func (c *Client) NewStream(ctx context.Context, method string, req proto.Message) (*Stream, error) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshaling request: %v", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.BaseURL, "/")+method, bytes.NewReader(frame(0, data)))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for key, values := range c.Header {
+		httpReq.Header[key] = values
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Grpc-Web", "1")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, status.Errorf(codes.Unknown, "unexpected HTTP status %s", res.Status)
+	}
+
+	return &Stream{Header: res.Header, body: res.Body, r: bufio.NewReader(res.Body)}, nil
+}
+
+// Stream reads the responses of one call.
+type Stream struct {
+	// Header holds the response headers, Trailer the trailers once Recv returned io.EOF or an error.
+	Header  http.Header
+	Trailer http.Header
+
+	body io.ReadCloser
+	r    *bufio.Reader
+}
+
+// Recv reads the next message into m. It returns io.EOF once the call
+// finished with OK and the status error of the call otherwise.
+func (s *Stream) Recv(m proto.Message) error {
+	var prefix [5]byte
+	if _, err := io.ReadFull(s.r, prefix[:]); err != nil {
+		if err == io.EOF {
+			// Calls that fail before the first message answer with headers only.
+			s.Trailer = s.Header
+			return s.status()
+		}
+		return status.Errorf(codes.Unavailable, "reading frame: %v", err)
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+	if _, err := io.ReadFull(s.r, payload); err != nil {
+		return status.Errorf(codes.Unavailable, "reading frame: %v", err)
+	}
+
+	if prefix[0]&trailerFlag != 0 {
+		trailer, err := textproto.NewReader(bufio.NewReader(bytes.NewReader(append(payload, '\r', '\n')))).ReadMIMEHeader()
+		if err != nil && err != io.EOF {
+			return status.Errorf(codes.Internal, "parsing trailers: %v", err)
+		}
+		s.Trailer = http.Header(trailer)
+		return s.status()
+	}
+
+	if err := proto.Unmarshal(payload, m); err != nil {
+		return status.Errorf(codes.Internal, "unmarshaling response: %v", err)
+	}
+
+	return nil
+}
+
+// Close releases the connection, canceling the call if it is still running.
+func (s *Stream) Close() error {
+	return s.body.Close()
+}
+
+func (s *Stream) status() error {
+	value := s.Trailer.Get("Grpc-Status")
+	if value == "" {
+		return status.Error(codes.Internal, "call ended without a grpc-status")
+	}
+	code, err := strconv.Atoi(value)
+	if err != nil {
+		return status.Errorf(codes.Internal, "malformed grpc-status %q", value)
+	}
+	if codes.Code(code) == codes.OK {
+		return io.EOF
+	}
+
+	message, err := url.PathUnescape(s.Trailer.Get("Grpc-Message"))
+	if err != nil {
+		message = s.Trailer.Get("Grpc-Message")
+	}
+
+	return status.Error(codes.Code(code), message)
+}
+
+func frame(flag byte, data []byte) []byte {
+	buf := make([]byte, 5+len(data))
+	buf[0] = flag
+	binary.BigEndian.PutUint32(buf[1:], uint32(len(data)))
+	copy(buf[5:], data)
+
+	return buf
+}
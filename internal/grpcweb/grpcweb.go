@@ -0,0 +1,92 @@
+// Package grpcweb serves a grpc.Server to browsers through the gRPC-Web
+// protocol, on a listener of its own that speaks HTTP/1.1 and HTTP/2 (h2c in
+// cleartext, negotiated through ALPN with TLS). Calls go through the same
+// interceptors as native gRPC calls; only unary and server-streaming RPCs are
+// usable, as gRPC-Web has no client streaming.
+//
+// The protocol is implemented by github.com/improbable-eng/grpc-web, which is
+// archived but feature complete; only NewHandler depends on it.
+package grpcweb
+
+import (
+	"crypto/tls"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// allowedHeaders are the request headers browsers may send: those of the
+// gRPC-Web protocol, credentials and W3C trace context.
+var allowedHeaders = []string{
+	"content-type", "x-grpc-web", "x-user-agent", "grpc-timeout",
+	"authorization", "traceparent", "tracestate",
+}
+
+// NewHandler wraps s. Cross-origin requests are only answered for the
+// allowed origins; "*" allows every origin.
+func NewHandler(s *grpc.Server, allowedOrigins []string) http.Handler {
+	wrapped := grpcweb.WrapServer(s,
+		grpcweb.WithOriginFunc(originFunc(allowedOrigins)),
+		grpcweb.WithAllowedRequestHeaders(allowedHeaders),
+		grpcweb.WithCorsForRegisteredEndpointsOnly(true),
+	)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !wrapped.IsGrpcWebRequest(r) && !wrapped.IsAcceptableGrpcCorsRequest(r) {
+			http.Error(w, "only gRPC-Web requests are served here", http.StatusUnsupportedMediaType)
+			return
+		}
+		wrapped.ServeHTTP(w, r)
+	})
+}
+
+// Listen binds addr and returns the server that serves handler on it, together
+// with the listener to serve. With tlsConfig, normally the one the gRPC
+// listener uses, connections are served over TLS; nil serves cleartext. The
+// server's Addr is the bound address. Give both to
+// bootstrap.Runner.AddHTTPServer: grpc.Server cannot drain the calls it serves
+// through ServeHTTP, the Runner shuts the server down before it does.
+func Listen(addr string, handler http.Handler, tlsConfig *tls.Config) (*http.Server, net.Listener, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// configured on srv, h2s also sends GOAWAY to the connections h2c took over
+	// when srv shuts down
+	h2s := &http2.Server{}
+	srv := &http.Server{Addr: lis.Addr().String(), ReadHeaderTimeout: 10 * time.Second}
+	if tlsConfig != nil {
+		srv.Handler = handler
+		srv.TLSConfig = tlsConfig.Clone()
+		srv.TLSConfig.NextProtos = []string{"h2", "http/1.1"}
+	} else {
+		srv.Handler = h2c.NewHandler(handler, h2s)
+	}
+	if err := http2.ConfigureServer(srv, h2s); err != nil {
+		lis.Close()
+		return nil, nil, err
+	}
+	if tlsConfig != nil {
+		lis = tls.NewListener(lis, srv.TLSConfig)
+	}
+	slog.Info("serving gRPC-Web", slog.String("addr", lis.Addr().String()), slog.Bool("tls", tlsConfig != nil))
+
+	return srv, lis, nil
+}
+
+func originFunc(allowed []string) func(origin string) bool {
+	set := make(map[string]bool, len(allowed))
+	for _, origin := range allowed {
+		set[origin] = true
+	}
+
+	return func(origin string) bool {
+		return set["*"] || set[origin]
+	}
+}
@@ -0,0 +1,111 @@
+package grpcweb
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"grpc-go-course/internal/certgen"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOriginFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		origin  string
+		want    bool
+	}{
+		{"listed", []string{"https://dashboard.example.com"}, "https://dashboard.example.com", true},
+		{"not listed", []string{"https://dashboard.example.com"}, "https://evil.example.com", false},
+		{"other scheme", []string{"https://dashboard.example.com"}, "http://dashboard.example.com", false},
+		{"none allowed", nil, "https://dashboard.example.com", false},
+		{"wildcard", []string{"*"}, "https://evil.example.com", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := originFunc(test.allowed)(test.origin); got != test.want {
+				t.Errorf("origin %q allowed = %v, want %v", test.origin, got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewHandlerAnswersPreflightsOfAllowedOrigins(t *testing.T) {
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	handler := NewHandler(s, []string{"https://dashboard.example.com"})
+
+	for origin, allowed := range map[string]bool{
+		"https://dashboard.example.com": true,
+		"https://evil.example.com":      false,
+	} {
+		req := httptest.NewRequest(http.MethodOptions, "/grpc.health.v1.Health/Check", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,authorization")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		got := rec.Header().Get("Access-Control-Allow-Origin")
+		if allowed && got != origin {
+			t.Errorf("preflight from %s: Access-Control-Allow-Origin = %q, want %q", origin, got, origin)
+		}
+		if !allowed && got != "" {
+			t.Errorf("preflight from %s: Access-Control-Allow-Origin = %q, want none", origin, got)
+		}
+	}
+}
+
+func TestListenServesTheGivenTLSConfig(t *testing.T) {
+	ca, err := certgen.NewCA("test CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := ca.Issue(certgen.LeafOptions{CommonName: "server", Hosts: []string{"127.0.0.1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := tls.X509KeyPair(leaf.CertPEM, leaf.KeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var handshakes atomic.Int32
+	tlsConfig := &tls.Config{GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		handshakes.Add(1)
+		return &cert, nil
+	}}
+	web, lis, err := Listen("127.0.0.1:0", NewHandler(grpc.NewServer(), nil), tlsConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go web.Serve(lis)
+	t.Cleanup(func() { web.Close() })
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: roots},
+		ForceAttemptHTTP2: true,
+	}}
+	res, err := client.Get("https://" + web.Addr + "/")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	res.Body.Close()
+
+	if res.Proto != "HTTP/2.0" {
+		t.Errorf("served over %s, want HTTP/2.0", res.Proto)
+	}
+	if handshakes.Load() == 0 {
+		t.Error("the handshake did not use the given tls.Config")
+	}
+	if len(tlsConfig.NextProtos) != 0 {
+		t.Errorf("Listen changed the given tls.Config: NextProtos = %v", tlsConfig.NextProtos)
+	}
+}
@@ -0,0 +1,115 @@
+// Package healthcheck wires the standard grpc.health.v1.Health service into our
+// servers and gives the process a single place to flip the status of each of
+// its services, e.g. while draining or while a dependency is down.
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// Checker keeps the serving status of a fixed set of services. The empty
+// service name reports the server as a whole and is SERVING only while every
+// registered service is.
+type Checker struct {
+	*health.Server
+
+	mu       sync.Mutex
+	services map[string]bool
+}
+
+// New creates a Checker that reports every given service as SERVING.
+func New(services ...string) *Checker {
+	c := &Checker{
+		Server:   health.NewServer(),
+		services: make(map[string]bool, len(services)),
+	}
+	for _, service := range services {
+		c.services[service] = true
+		c.Server.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	c.Server.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+
+	return c
+}
+
+// Register exposes the checker on s as grpc.health.v1.Health.
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.Server)
+}
+
+// SetServing flips the status of a single service and updates the overall status.
+func (c *Checker) SetServing(service string, serving bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.services[service]; !ok {
+		slog.Warn("ignoring health status change for unknown service", slog.String("service", service))
+		return
+	}
+	c.services[service] = serving
+	c.Server.SetServingStatus(service, servingStatus(serving))
+
+	overall := true
+	for _, ok := range c.services {
+		overall = overall && ok
+	}
+	c.Server.SetServingStatus("", servingStatus(overall))
+
+	slog.Info("health status changed", slog.String("service", service), slog.String("status", servingStatus(serving).String()))
+}
+
+// SetAllServing flips the status of every service at once.
+func (c *Checker) SetAllServing(serving bool) {
+	c.mu.Lock()
+	services := make([]string, 0, len(c.services))
+	for service := range c.services {
+		services = append(services, service)
+	}
+	c.mu.Unlock()
+
+	for _, service := range services {
+		c.SetServing(service, serving)
+	}
+}
+
+// Probe asks the health service behind conn for the status of service and
+// returns an error unless it is SERVING.
+func Probe(ctx context.Context, conn grpc.ClientConnInterface, service string) error {
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return fmt.Errorf("health check for %q failed: %w", service, err)
+	}
+	if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("service %q is %v", service, res.GetStatus())
+	}
+
+	return nil
+}
+
+// Run is the -healthcheck mode of the clients: it probes service behind conn,
+// reports the outcome on stdout or stderr and returns the exit code of the
+// process, 0 when service is SERVING and 1 otherwise.
+func Run(ctx context.Context, conn grpc.ClientConnInterface, service string, stdout, stderr io.Writer) int {
+	if err := Probe(ctx, conn, service); err != nil {
+		fmt.Fprintf(stderr, "unhealthy: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "%s is SERVING\n", service)
+	return 0
+}
+
+func servingStatus(serving bool) healthpb.HealthCheckResponse_ServingStatus {
+	if serving {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
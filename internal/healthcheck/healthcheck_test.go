@@ -0,0 +1,85 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/internal/grpctest"
+	"strings"
+	"testing"
+)
+
+func TestChecker(t *testing.T) {
+	checker := New("greet.GreetService", "calculator.CalculatorService")
+	s := grpc.NewServer()
+	checker.Register(s)
+	conn := grpctest.Dial(t, s)
+
+	// serving lists the services whose probe must pass, the others must fail
+	steps := []struct {
+		name    string
+		change  func()
+		serving []string
+	}{
+		{"initially", func() {}, []string{"", "greet.GreetService", "calculator.CalculatorService"}},
+		{"one service down", func() { checker.SetServing("greet.GreetService", false) }, []string{"calculator.CalculatorService"}},
+		{"unknown service", func() { checker.SetServing("other.Service", true) }, []string{"calculator.CalculatorService"}},
+		{"back up", func() { checker.SetServing("greet.GreetService", true) }, []string{"", "greet.GreetService", "calculator.CalculatorService"}},
+		{"all down", func() { checker.SetAllServing(false) }, nil},
+		{"all up", func() { checker.SetAllServing(true) }, []string{"", "greet.GreetService", "calculator.CalculatorService"}},
+	}
+
+	for _, step := range steps {
+		step.change()
+		for _, service := range []string{"", "greet.GreetService", "calculator.CalculatorService"} {
+			want := false
+			for _, serving := range step.serving {
+				want = want || serving == service
+			}
+			if err := Probe(context.Background(), conn, service); (err == nil) != want {
+				t.Errorf("%s: Probe(%q) = %v, want serving %v", step.name, service, err, want)
+			}
+		}
+	}
+
+	err := Probe(context.Background(), conn, "other.Service")
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Probe of an unregistered service returned %v, want NotFound", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	checker := New("greet.GreetService")
+	s := grpc.NewServer()
+	checker.Register(s)
+	conn := grpctest.Dial(t, s)
+	withoutHealth := grpctest.Dial(t, grpc.NewServer())
+
+	tests := []struct {
+		name    string
+		conn    *grpc.ClientConn
+		serving bool
+		code    int
+		output  string
+	}{
+		{"serving", conn, true, 0, "greet.GreetService is SERVING"},
+		{"not serving", conn, false, 1, "unhealthy: service \"greet.GreetService\" is NOT_SERVING"},
+		{"no health service", withoutHealth, true, 1, "code = Unimplemented"},
+	}
+
+	for _, tt := range tests {
+		checker.SetAllServing(tt.serving)
+		var stdout, stderr bytes.Buffer
+
+		code := Run(context.Background(), tt.conn, "greet.GreetService", &stdout, &stderr)
+		output := stdout.String()
+		if code != 0 {
+			output = stderr.String()
+		}
+		if code != tt.code || !strings.Contains(output, tt.output) {
+			t.Errorf("%s: Run = %d with %q, want %d with %q", tt.name, code, output, tt.code, tt.output)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// redacted replaces string and bytes fields of logged payloads.
+const redacted = "[REDACTED]"
+
+// Options tunes what the interceptors record.
+type Options struct {
+	// Payloads adds the request messages to the log.
+	Payloads bool
+	// Redact blanks out every string and bytes field of logged payloads.
+	Redact bool
+}
+
+// Interceptor logs every call with its method, peer, duration, status code
+// and, for streams, the number of messages sent and received.
+type Interceptor struct {
+	logger *slog.Logger
+	opts   Options
+}
+
+// NewInterceptor creates an Interceptor writing to logger.
+func NewInterceptor(logger *slog.Logger, opts Options) *Interceptor {
+	return &Interceptor{logger: logger, opts: opts}
+}
+
+// ServerOptions returns the logging interceptors. They should come early in
+// the chain so that calls rejected by later interceptors are logged too.
+func (i *Interceptor) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(i.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(i.StreamServerInterceptor),
+	}
+}
+
+// UnaryServerInterceptor implements grpc.UnaryServerInterceptor.
+func (i *Interceptor) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx, fields := i.newCallContext(ctx, info.FullMethod)
+
+	resp, err := handler(ctx, req)
+
+	attrs := fields.list()
+	if i.opts.Payloads {
+		attrs = append(attrs, slog.Any("request", i.payload(req)))
+	}
+	i.finish(ctx, "finished unary call", start, err, attrs)
+
+	return resp, err
+}
+
+// StreamServerInterceptor implements grpc.StreamServerInterceptor.
+func (i *Interceptor) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, fields := i.newCallContext(ss.Context(), info.FullMethod)
+	stream := &serverStream{ServerStream: ss, ctx: ctx, interceptor: i}
+
+	err := handler(srv, stream)
+
+	attrs := append(fields.list(),
+		slog.Int64("msgs_received", atomic.LoadInt64(&stream.received)),
+		slog.Int64("msgs_sent", atomic.LoadInt64(&stream.sent)),
+	)
+	i.finish(ctx, "finished streaming call", start, err, attrs)
+
+	return err
+}
+
+func (i *Interceptor) newCallContext(ctx context.Context, method string) (context.Context, *callFields) {
+	fields := &callFields{}
+	fields.add("method", method, "peer", peerAddr(ctx))
+
+	ctx = context.WithValue(ctx, fieldsKey{}, fields)
+	ctx = NewContext(ctx, i.logger.With("method", method, "peer", peerAddr(ctx)))
+
+	return ctx, fields
+}
+
+func (i *Interceptor) finish(ctx context.Context, msg string, start time.Time, err error, attrs []any) {
+	code := status.Code(err)
+	attrs = append(attrs,
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.String("code", code.String()),
+	)
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+
+	i.logger.Log(ctx, levelFor(code), msg, attrs...)
+}
+
+// payload renders a message as JSON, redacted when configured.
+func (i *Interceptor) payload(msg interface{}) any {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return msg
+	}
+	if i.opts.Redact {
+		m = proto.Clone(m)
+		redact(m.ProtoReflect())
+	}
+
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return err.Error()
+	}
+
+	return json.RawMessage(data)
+}
+
+// redact blanks out string and bytes fields, recursing into nested messages.
+func redact(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redact(list.Get(i).Message())
+			}
+		case fd.IsList() && (fd.Kind() == protoreflect.StringKind || fd.Kind() == protoreflect.BytesKind):
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				list.Set(i, redactedValue(fd))
+			}
+		case fd.IsMap():
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				if fd.MapValue().Message() != nil {
+					redact(mv.Message())
+				} else if kind := fd.MapValue().Kind(); kind == protoreflect.StringKind || kind == protoreflect.BytesKind {
+					v.Map().Set(k, redactedValue(fd.MapValue()))
+				}
+				return true
+			})
+		case fd.Message() != nil:
+			redact(v.Message())
+		case fd.Kind() == protoreflect.StringKind || fd.Kind() == protoreflect.BytesKind:
+			m.Set(fd, redactedValue(fd))
+		}
+		return true
+	})
+}
+
+func redactedValue(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	if fd.Kind() == protoreflect.BytesKind {
+		return protoreflect.ValueOfBytes([]byte(redacted))
+	}
+
+	return protoreflect.ValueOfString(redacted)
+}
+
+// levelFor logs failures caused by the caller as warnings and failures of the server as errors.
+func levelFor(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition,
+		codes.OutOfRange, codes.DeadlineExceeded:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+
+	return ""
+}
+
+// serverStream counts messages and exposes the call logger through Context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx         context.Context
+	interceptor *Interceptor
+	sent        int64
+	received    int64
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		atomic.AddInt64(&s.sent, 1)
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		atomic.AddInt64(&s.received, 1)
+		if s.interceptor.opts.Payloads {
+			FromContext(s.ctx).Debug("received message", slog.Any("message", s.interceptor.payload(m)))
+		}
+	}
+	return err
+}
+
+type fieldsKey struct{}
+
+// callFields collects the attributes of the finishing log line of a call.
+type callFields struct {
+	mu    sync.Mutex
+	attrs []any
+}
+
+func (f *callFields) add(args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.attrs = append(f.attrs, args...)
+}
+
+func (f *callFields) list() []any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]any(nil), f.attrs...)
+}
@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/greet/greetpb"
+	"strings"
+	"testing"
+)
+
+func runUnary(t *testing.T, opts Options, req interface{}, handler grpc.UnaryHandler) map[string]interface{} {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger, err := New(&buf, "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/greet.GreetService/Greet"}
+	NewInterceptor(logger, opts).UnaryServerInterceptor(context.Background(), req, info, handler)
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("log line %q is not JSON: %v", buf.String(), err)
+	}
+	return line
+}
+
+func TestUnaryCallIsLogged(t *testing.T) {
+	line := runUnary(t, Options{}, &greetpb.GreetRequest{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		With(ctx, "subject", "alice")
+		return nil, status.Error(codes.InvalidArgument, "bad input")
+	})
+
+	want := map[string]interface{}{
+		"level":   "WARN",
+		"method":  "/greet.GreetService/Greet",
+		"code":    "InvalidArgument",
+		"error":   "bad input",
+		"subject": "alice",
+	}
+	for key, value := range want {
+		if line[key] != value {
+			t.Errorf("%s = %v, want %v", key, line[key], value)
+		}
+	}
+	if _, ok := line["duration_ms"]; !ok {
+		t.Error("duration_ms missing")
+	}
+	if _, ok := line["request"]; ok {
+		t.Error("request logged although payloads are off")
+	}
+}
+
+func TestPayloadRedaction(t *testing.T) {
+	req := &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Alice", LastName: "Secret"}}
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+	plain := runUnary(t, Options{Payloads: true}, req, ok)
+	if !strings.Contains(toJSON(t, plain["request"]), "Secret") {
+		t.Errorf("request = %v, want the plain payload", plain["request"])
+	}
+
+	redactedLine := runUnary(t, Options{Payloads: true, Redact: true}, req, ok)
+	got := toJSON(t, redactedLine["request"])
+	if strings.Contains(got, "Alice") || strings.Contains(got, "Secret") || !strings.Contains(got, redacted) {
+		t.Errorf("request = %s, want every string redacted", got)
+	}
+	if req.GetGreeting().GetLastName() != "Secret" {
+		t.Error("redaction modified the request itself")
+	}
+}
+
+func toJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,59 @@
+// Package logging provides the JSON slog logger used by the servers and the
+// interceptors that write one structured line per finished call. Handlers log
+// through FromContext, which returns a logger already tagged with the method
+// and peer of the call.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+type loggerKey struct{}
+
+// New returns a JSON logger writing records of at least level to w.
+func New(w io.Writer, level string) (*slog.Logger, error) {
+	l, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: l})), nil
+}
+
+// ParseLevel understands debug, info, warn and error.
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", level)
+	}
+
+	return l, nil
+}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of the current call, or slog.Default() outside of one.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// With adds attributes to the logger in ctx and to the line written when the
+// call finishes, e.g. the caller identity once it has been verified.
+func With(ctx context.Context, args ...any) context.Context {
+	if fields, ok := ctx.Value(fieldsKey{}).(*callFields); ok {
+		fields.add(args...)
+	}
+
+	return NewContext(ctx, FromContext(ctx).With(args...))
+}
@@ -0,0 +1,193 @@
+// Package metrics records Prometheus metrics for every call a server handles
+// and serves them on a separate HTTP listener, so that scraping does not go
+// through the gRPC port, its TLS setup or its authentication.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics holds the registry of a server and the per-call collectors.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	handled  *prometheus.CounterVec
+	handling *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+	messages *prometheus.HistogramVec
+}
+
+// New creates a registry with the call collectors and the Go runtime and
+// process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Calls completed on the server, by method and status code.",
+		}, []string{"grpc_service", "grpc_method", "grpc_type", "grpc_code"}),
+		handling: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Time from the start of a call until the handler returned.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 4, 10),
+		}, []string{"grpc_service", "grpc_method", "grpc_type"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "grpc_server_in_flight",
+			Help: "Calls currently being handled.",
+		}, []string{"grpc_service", "grpc_method", "grpc_type"}),
+		messages: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_stream_messages",
+			Help:    "Messages received from or sent to the client per finished stream.",
+			Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{"grpc_service", "grpc_method", "grpc_type", "direction"}),
+	}
+
+	m.registry.MustRegister(
+		m.handled, m.handling, m.inFlight, m.messages,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// MustRegister adds collectors of the server itself, e.g. domain metrics.
+func (m *Metrics) MustRegister(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ListenAndServe binds addr and serves /metrics on it in the background. The
+// returned server should be shut down together with the gRPC server.
+func (m *Metrics) ListenAndServe(addr string) (*http.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics listener failed", slog.String("addr", addr), slog.Any("error", err))
+		}
+	}()
+	slog.Info("serving metrics", slog.String("addr", lis.Addr().String()))
+
+	return srv, nil
+}
+
+// ServerOptions returns the interceptors recording the call metrics.
+func (m *Metrics) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(m.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(m.StreamServerInterceptor),
+	}
+}
+
+// UnaryServerInterceptor implements grpc.UnaryServerInterceptor.
+func (m *Metrics) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	labels := newLabels(info.FullMethod, "unary")
+	done := m.start(labels)
+
+	resp, err := handler(ctx, req)
+	done(err)
+
+	return resp, err
+}
+
+// StreamServerInterceptor implements grpc.StreamServerInterceptor.
+func (m *Metrics) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	labels := newLabels(info.FullMethod, streamType(info))
+	done := m.start(labels)
+	stream := &serverStream{ServerStream: ss}
+
+	err := handler(srv, stream)
+	done(err)
+
+	m.messages.WithLabelValues(labels.service, labels.method, labels.typ, "received").Observe(float64(atomic.LoadInt64(&stream.received)))
+	m.messages.WithLabelValues(labels.service, labels.method, labels.typ, "sent").Observe(float64(atomic.LoadInt64(&stream.sent)))
+
+	return err
+}
+
+// start counts the call as in flight and returns the function recording its end.
+func (m *Metrics) start(l labels) func(err error) {
+	start := time.Now()
+	inFlight := m.inFlight.WithLabelValues(l.service, l.method, l.typ)
+	inFlight.Inc()
+
+	return func(err error) {
+		inFlight.Dec()
+		m.handling.WithLabelValues(l.service, l.method, l.typ).Observe(time.Since(start).Seconds())
+		m.handled.WithLabelValues(l.service, l.method, l.typ, status.Code(err).String()).Inc()
+	}
+}
+
+type labels struct {
+	service string
+	method  string
+	typ     string
+}
+
+// newLabels splits "/package.Service/Method".
+func newLabels(fullMethod string, typ string) labels {
+	service, method := "unknown", "unknown"
+	if parts := strings.SplitN(strings.TrimPrefix(fullMethod, "/"), "/", 2); len(parts) == 2 {
+		service, method = parts[0], parts[1]
+	}
+
+	return labels{service: service, method: method, typ: typ}
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
+
+// serverStream counts the messages of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	sent     int64
+	received int64
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		atomic.AddInt64(&s.sent, 1)
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		atomic.AddInt64(&s.received, 1)
+	}
+	return err
+}
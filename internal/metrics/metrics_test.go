@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUnaryCallsAreCountedByCode(t *testing.T) {
+	m := New()
+	info := &grpc.UnaryServerInfo{FullMethod: "/calculator.CalculatorService/SquareRoot"}
+
+	m.UnaryServerInterceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	m.UnaryServerInterceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "negative")
+	})
+
+	for code, want := range map[codes.Code]float64{codes.OK: 1, codes.InvalidArgument: 1} {
+		got := testutil.ToFloat64(m.handled.WithLabelValues("calculator.CalculatorService", "SquareRoot", "unary", code.String()))
+		if got != want {
+			t.Errorf("handled{code=%v} = %v, want %v", code, got, want)
+		}
+	}
+	if got := testutil.ToFloat64(m.inFlight.WithLabelValues("calculator.CalculatorService", "SquareRoot", "unary")); got != 0 {
+		t.Errorf("in flight = %v after the calls finished", got)
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	pending int
+}
+
+func (s *fakeStream) Context() context.Context  { return context.Background() }
+func (s *fakeStream) SendMsg(interface{}) error { return nil }
+func (s *fakeStream) RecvMsg(interface{}) error {
+	if s.pending == 0 {
+		return io.EOF
+	}
+	s.pending--
+	return nil
+}
+
+func TestStreamMessagesAreCounted(t *testing.T) {
+	m := New()
+	info := &grpc.StreamServerInfo{FullMethod: "/calculator.CalculatorService/FindMaximum", IsClientStream: true, IsServerStream: true}
+
+	err := m.StreamServerInterceptor(nil, &fakeStream{pending: 3}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		for ss.RecvMsg(nil) == nil {
+			if err := ss.SendMsg(nil); err != nil {
+				return err
+			}
+		}
+		return ss.SendMsg(nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		`grpc_server_stream_messages_sum{direction="received",grpc_method="FindMaximum",grpc_service="calculator.CalculatorService",grpc_type="bidi_stream"} 3`,
+		`grpc_server_stream_messages_sum{direction="sent",grpc_method="FindMaximum",grpc_service="calculator.CalculatorService",grpc_type="bidi_stream"} 4`,
+		`grpc_server_handled_total{grpc_code="OK",grpc_method="FindMaximum",grpc_service="calculator.CalculatorService",grpc_type="bidi_stream"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics does not contain %s", want)
+		}
+	}
+}
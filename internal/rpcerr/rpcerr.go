@@ -0,0 +1,132 @@
+// Package rpcerr builds status errors that carry google.rpc error details and
+// reads those details back, so that clients branch on typed fields instead of
+// matching messages.
+//
+// Servers attach a BadRequest to validation failures, a RetryInfo to failures
+// worth retrying and an ErrorInfo, with a stable reason and domain, to internal
+// failures. Clients use FieldViolations, Violation, RetryDelay and Info on the
+// error returned by a call, or on status.FromProto(p).Err() for statuses that
+// travel inside messages.
+package rpcerr
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"time"
+)
+
+// Field describes what is wrong with one request field.
+func Field(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{Field: field, Description: description}
+}
+
+// InvalidArgument returns an InvalidArgument error about a single field. The
+// formatted description is both the message and the violation description.
+func InvalidArgument(field, format string, args ...interface{}) error {
+	description := fmt.Sprintf(format, args...)
+	return BadRequest(codes.InvalidArgument, description, Field(field, description))
+}
+
+// BadRequest returns an error with the given code and message carrying the
+// violations, for failures that are the fault of the request but are not
+// InvalidArgument, such as OutOfRange, or that involve several fields.
+func BadRequest(code codes.Code, message string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	return withDetails(status.New(code, message), &errdetails.BadRequest{FieldViolations: violations})
+}
+
+// Retryable returns an error telling the client it may send the same request
+// again after delay.
+func Retryable(code codes.Code, delay time.Duration, format string, args ...interface{}) error {
+	return withDetails(status.Newf(code, format, args...), &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+}
+
+// Internal returns an Internal error identified by reason, an UPPER_SNAKE_CASE
+// constant that never changes once published, within domain, usually the
+// name of the service. metadata may be nil.
+func Internal(domain, reason string, metadata map[string]string, format string, args ...interface{}) error {
+	return withDetails(status.Newf(codes.Internal, format, args...), &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   domain,
+		Metadata: metadata,
+	})
+}
+
+// withDetails returns st with details attached, or without them in the
+// unlikely case they cannot be marshaled.
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// FieldViolations returns the field violations of all BadRequest details of
+// err, or nil when it has none.
+func FieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range details(err) {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = append(violations, badRequest.GetFieldViolations()...)
+		}
+	}
+	return violations
+}
+
+// Violation returns the description of the first violation of field in err.
+func Violation(err error, field string) (string, bool) {
+	for _, violation := range FieldViolations(err) {
+		if violation.GetField() == field {
+			return violation.GetDescription(), true
+		}
+	}
+	return "", false
+}
+
+// RetryDelay returns how long the server asked to wait before retrying, and
+// false when err carries no RetryInfo and should not be retried as is.
+func RetryDelay(err error) (time.Duration, bool) {
+	for _, detail := range details(err) {
+		if retry, ok := detail.(*errdetails.RetryInfo); ok {
+			return retry.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// Info returns the ErrorInfo detail of err.
+func Info(err error) (*errdetails.ErrorInfo, bool) {
+	for _, detail := range details(err) {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info, true
+		}
+	}
+	return nil, false
+}
+
+// HasReason reports whether err carries an ErrorInfo with reason in domain.
+func HasReason(err error, domain, reason string) bool {
+	info, ok := Info(err)
+	return ok && info.GetDomain() == domain && info.GetReason() == reason
+}
+
+// details returns the decoded details of err, skipping those of unknown types.
+func details(err error) []interface{} {
+	var detailed interface{ GRPCStatus() *status.Status }
+	if err == nil || !errors.As(err, &detailed) {
+		return nil
+	}
+
+	var known []interface{}
+	for _, detail := range detailed.GRPCStatus().Details() {
+		if _, failed := detail.(error); !failed {
+			known = append(known, detail)
+		}
+	}
+	return known
+}
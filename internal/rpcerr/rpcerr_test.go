@@ -0,0 +1,88 @@
+package rpcerr
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestInvalidArgument(t *testing.T) {
+	err := InvalidArgument("number", "%d is negative", -2)
+
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "-2 is negative" {
+		t.Errorf("InvalidArgument returned %v", err)
+	}
+	if description, ok := Violation(err, "number"); !ok || description != "-2 is negative" {
+		t.Errorf("Violation(number) = %q, %v", description, ok)
+	}
+	if _, ok := Violation(err, "other"); ok {
+		t.Errorf("Violation(other) found a violation")
+	}
+	if _, ok := RetryDelay(err); ok {
+		t.Errorf("a validation failure is retryable")
+	}
+}
+
+func TestBadRequest(t *testing.T) {
+	err := BadRequest(codes.OutOfRange, "too big", Field("a", "first"), Field("b", "second"))
+
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("BadRequest returned %v", err)
+	}
+	violations := FieldViolations(err)
+	if len(violations) != 2 || violations[0].GetField() != "a" || violations[1].GetDescription() != "second" {
+		t.Errorf("FieldViolations = %v", violations)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	err := Retryable(codes.Unavailable, 1500*time.Millisecond, "try %s", "later")
+
+	if delay, ok := RetryDelay(err); !ok || delay != 1500*time.Millisecond {
+		t.Errorf("RetryDelay = %v, %v", delay, ok)
+	}
+	if FieldViolations(err) != nil {
+		t.Errorf("a retryable failure has field violations")
+	}
+}
+
+func TestInternal(t *testing.T) {
+	err := Internal("calculator.CalculatorService", "STREAM_FAILURE", map[string]string{"op": "send"}, "boom")
+
+	info, ok := Info(err)
+	if status.Code(err) != codes.Internal || !ok || info.GetMetadata()["op"] != "send" {
+		t.Errorf("Internal returned %v with info %v", err, info)
+	}
+	if !HasReason(err, "calculator.CalculatorService", "STREAM_FAILURE") {
+		t.Errorf("HasReason did not find the reason")
+	}
+	if HasReason(err, "greet.GreetService", "STREAM_FAILURE") {
+		t.Errorf("HasReason ignored the domain")
+	}
+}
+
+func TestDetailsSurviveWrappingAndProtos(t *testing.T) {
+	err := InvalidArgument("number", "negative")
+
+	wrapped := fmt.Errorf("calling SquareRoot: %w", err)
+	if _, ok := Violation(wrapped, "number"); !ok {
+		t.Errorf("the violation was lost by wrapping")
+	}
+
+	fromProto := status.FromProto(status.Convert(err).Proto()).Err()
+	if _, ok := Violation(fromProto, "number"); !ok {
+		t.Errorf("the violation was lost in a google.rpc.Status")
+	}
+
+	for _, err := range []error{nil, errors.New("plain"), status.Error(codes.Internal, "bare")} {
+		if FieldViolations(err) != nil {
+			t.Errorf("FieldViolations(%v) is not empty", err)
+		}
+		if _, ok := Info(err); ok {
+			t.Errorf("Info(%v) found an ErrorInfo", err)
+		}
+	}
+}
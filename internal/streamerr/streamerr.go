@@ -0,0 +1,96 @@
+// Package streamerr turns failures seen while reading from or writing to a
+// gRPC stream into status errors that a handler can return, so that a single
+// misbehaving client ends its own call instead of the whole process.
+package streamerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/internal/logging"
+	"grpc-go-course/internal/rpcerr"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	// ReasonStreamFailure is the ErrorInfo reason of Internal errors, in the
+	// domain of the service, for stream failures nothing else explains.
+	ReasonStreamFailure = "STREAM_FAILURE"
+
+	// RetryDelay is the RetryInfo delay of Unavailable errors: the transport
+	// broke, so the call may well succeed on a new connection.
+	RetryDelay = time.Second
+)
+
+// Recv translates an error returned by stream.Recv() and logs it for the current call.
+func Recv(stream grpc.ServerStream, err error) error {
+	return translate(stream, "reading client stream", err)
+}
+
+// Send translates an error returned by stream.Send() and logs it for the current call.
+func Send(stream grpc.ServerStream, err error) error {
+	return translate(stream, "sending data to client", err)
+}
+
+func translate(stream grpc.ServerStream, op string, err error) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	code := Code(stream.Context(), err)
+	logging.FromContext(stream.Context()).Warn("stream failed",
+		slog.String("op", op), slog.String("code", code.String()), slog.Any("error", err))
+
+	message := fmt.Sprintf("%s: error while %s: %v", method, op, errorMessage(err))
+	switch code {
+	case codes.Unavailable:
+		return rpcerr.Retryable(code, RetryDelay, "%s", message)
+	case codes.Internal:
+		return rpcerr.Internal(service(method), ReasonStreamFailure, map[string]string{"method": method}, "%s", message)
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == code {
+		// keep the details, such as the violations of an invalid message
+		p := s.Proto()
+		p.Message = message
+		return status.FromProto(p).Err()
+	}
+	return status.Error(code, message)
+}
+
+// service returns the service part of a full method name such as
+// /calculator.CalculatorService/FindMaximum.
+func service(method string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return service
+}
+
+// Code picks the status code that best describes err given the state of the call context:
+// codes.Canceled or codes.DeadlineExceeded when the client went away, codes.Unavailable
+// when the transport broke, the original code for status errors and codes.Internal otherwise.
+func Code(ctx context.Context, err error) codes.Code {
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled), errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(ctx.Err(), context.DeadlineExceeded), errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.ErrClosedPipe):
+		return codes.Unavailable
+	}
+
+	if s, ok := status.FromError(err); ok && s.Code() != codes.Unknown && s.Code() != codes.OK {
+		return s.Code()
+	}
+
+	return codes.Internal
+}
+
+func errorMessage(err error) string {
+	if s, ok := status.FromError(err); ok {
+		return s.Message()
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,117 @@
+package streamerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/internal/rpcerr"
+	"io"
+	"testing"
+	"time"
+)
+
+// fakeStream is a server stream of the given method whose context never ends.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s fakeStream) Context() context.Context { return s.ctx }
+
+// transportStream only provides the method name.
+type transportStream struct{ method string }
+
+func (s transportStream) Method() string               { return s.method }
+func (s transportStream) SetHeader(metadata.MD) error  { return nil }
+func (s transportStream) SendHeader(metadata.MD) error { return nil }
+func (s transportStream) SetTrailer(metadata.MD) error { return nil }
+
+func newFakeStream(method string) fakeStream {
+	return fakeStream{ctx: grpc.NewContextWithServerTransportStream(context.Background(), transportStream{method})}
+}
+
+func TestTranslateAttachesDetails(t *testing.T) {
+	stream := newFakeStream("/calculator.CalculatorService/FindMaximum")
+
+	broken := Recv(stream, io.ErrUnexpectedEOF)
+	if status.Code(broken) != codes.Unavailable {
+		t.Errorf("a broken transport became %v", broken)
+	}
+	if delay, ok := rpcerr.RetryDelay(broken); !ok || delay != RetryDelay {
+		t.Errorf("RetryDelay(%v) = %v, %v", broken, delay, ok)
+	}
+
+	failed := Send(stream, errors.New("disk on fire"))
+	if status.Code(failed) != codes.Internal {
+		t.Errorf("an unknown failure became %v", failed)
+	}
+	if !rpcerr.HasReason(failed, "calculator.CalculatorService", ReasonStreamFailure) {
+		t.Errorf("an unknown failure carries no %s reason: %v", ReasonStreamFailure, failed)
+	}
+	if _, ok := rpcerr.RetryDelay(failed); ok {
+		t.Errorf("an internal failure is retryable")
+	}
+
+	invalid := Recv(stream, rpcerr.InvalidArgument("number", "bad"))
+	if status.Code(invalid) != codes.InvalidArgument {
+		t.Errorf("a status error became %v", invalid)
+	}
+	if _, ok := rpcerr.Violation(invalid, "number"); !ok {
+		t.Errorf("a status error lost its details: %v", invalid)
+	}
+	if _, ok := rpcerr.Info(invalid); ok {
+		t.Errorf("a status error gained an ErrorInfo")
+	}
+}
+
+func TestCode(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want codes.Code
+	}{
+		{"client cancelled", cancelled, io.EOF, codes.Canceled},
+		{"client deadline", expired, io.EOF, codes.DeadlineExceeded},
+		{"canceled error", context.Background(), fmt.Errorf("recv: %w", context.Canceled), codes.Canceled},
+		{"deadline error", context.Background(), context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"eof", context.Background(), io.EOF, codes.Unavailable},
+		{"unexpected eof", context.Background(), io.ErrUnexpectedEOF, codes.Unavailable},
+		{"closed pipe", context.Background(), fmt.Errorf("write: %w", io.ErrClosedPipe), codes.Unavailable},
+		{"status error", context.Background(), status.Error(codes.ResourceExhausted, "too big"), codes.ResourceExhausted},
+		{"unknown status", context.Background(), status.Error(codes.Unknown, "?"), codes.Internal},
+		{"plain error", context.Background(), errors.New("disk on fire"), codes.Internal},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Code(test.ctx, test.err); got != test.want {
+				t.Errorf("Code(%v) = %v, want %v", test.err, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTranslateNamesTheMethodAndTheOperation(t *testing.T) {
+	stream := newFakeStream("/greet.GreetService/GreetEveryone")
+
+	for _, test := range []struct {
+		err  error
+		want string
+	}{
+		{Recv(stream, status.Error(codes.ResourceExhausted, "message too large")), "/greet.GreetService/GreetEveryone: error while reading client stream: message too large"},
+		{Send(stream, errors.New("broken pipe")), "/greet.GreetService/GreetEveryone: error while sending data to client: broken pipe"},
+	} {
+		if got := status.Convert(test.err).Message(); got != test.want {
+			t.Errorf("message = %q, want %q", got, test.want)
+		}
+	}
+}
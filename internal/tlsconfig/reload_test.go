@@ -0,0 +1,170 @@
+package tlsconfig
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"grpc-go-course/internal/certgen"
+	"grpc-go-course/internal/config"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+// servedSerial makes a call over conn and returns the serial of the certificate the server presented.
+func servedSerial(t *testing.T, conn *grpc.ClientConn) *big.Int {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var p peer.Peer
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Peer(&p)); err != nil {
+		t.Fatalf("health check failed: %v", err)
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		t.Fatalf("connection is not using TLS: %v", p.AuthInfo)
+	}
+
+	return info.State.PeerCertificates[0].SerialNumber
+}
+
+func dial(t *testing.T, addr string, caFile string) *grpc.ClientConn {
+	t.Helper()
+
+	transport, err := DialOption(config.TLS{Enabled: true, CAFile: caFile})
+	if err != nil {
+		t.Fatalf("DialOption: %v", err)
+	}
+	conn, err := grpc.Dial(addr, transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestServerPicksUpRotatedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := certgen.NewCA("test CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caFile, _, err := ca.WriteFiles(dir, "ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue := func() *certgen.Leaf {
+		leaf, err := ca.Issue(certgen.LeafOptions{CommonName: "server", Hosts: []string{"127.0.0.1"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return leaf
+	}
+
+	original := issue()
+	certFile, keyFile, err := original.WriteFiles(dir, "server")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tlsConfig, err := ServerConfig(ctx, config.TLS{
+		Enabled:        true,
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		ReloadInterval: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("ServerConfig: %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(ServerOptions(tlsConfig)...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	established := dial(t, lis.Addr().String(), caFile)
+	if got := servedSerial(t, established); got.Cmp(original.Cert.SerialNumber) != 0 {
+		t.Fatalf("served serial %x, want %x", got, original.Cert.SerialNumber)
+	}
+
+	rotated := issue()
+	if _, _, err := rotated.WriteFiles(dir, "server"); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		// every attempt is a fresh connection and therefore a fresh handshake
+		got := servedSerial(t, dial(t, lis.Addr().String(), caFile))
+		if got.Cmp(rotated.Cert.SerialNumber) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("new connections still see serial %x after rotating to %x", got, rotated.Cert.SerialNumber)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// the connection established before the rotation keeps working with its original certificate
+	if got := servedSerial(t, established); got.Cmp(original.Cert.SerialNumber) != 0 {
+		t.Fatalf("established connection now reports serial %x, want %x", got, original.Cert.SerialNumber)
+	}
+}
+
+func TestReloaderKeepsCertificateWhenPairIsBroken(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := certgen.NewCA("test CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := ca.Issue(certgen.LeafOptions{CommonName: "first"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ca.Issue(certgen.LeafOptions{CommonName: "second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile, err := first.WriteFiles(dir, "server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a rotation that has replaced only the certificate so far must not be picked up
+	if _, _, err := (&certgen.Leaf{CertPEM: second.CertPEM, KeyPEM: first.KeyPEM}).WriteFiles(dir, "server"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reload(); err == nil {
+		t.Fatalf("Reload accepted a certificate that does not match its key")
+	}
+	if got := r.Certificate().SerialNumber; got.Cmp(first.Cert.SerialNumber) != 0 {
+		t.Fatalf("serving serial %x, want the original %x", got, first.Cert.SerialNumber)
+	}
+
+	if _, _, err := second.WriteFiles(dir, "server"); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := r.Reload(); err != nil || !changed {
+		t.Fatalf("Reload() = %v, %v; want the completed rotation to be picked up", changed, err)
+	}
+	if got := r.Certificate().SerialNumber; got.Cmp(second.Cert.SerialNumber) != 0 {
+		t.Fatalf("serving serial %x, want %x", got, second.Cert.SerialNumber)
+	}
+}
@@ -0,0 +1,149 @@
+package tlsconfig
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"grpc-go-course/internal/certgen"
+	"grpc-go-course/internal/config"
+	"net"
+	"testing"
+	"time"
+)
+
+type testPKI struct {
+	caFile, serverCert, serverKey, clientCert, clientKey string
+}
+
+func newTestPKI(t *testing.T, dir string) testPKI {
+	t.Helper()
+
+	ca, err := certgen.NewCA("test CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := ca.Issue(certgen.LeafOptions{CommonName: "server", Hosts: []string{"localhost", "127.0.0.1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := ca.Issue(certgen.LeafOptions{CommonName: "client", Client: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pki testPKI
+	if pki.caFile, _, err = ca.WriteFiles(dir, "ca"); err != nil {
+		t.Fatal(err)
+	}
+	if pki.serverCert, pki.serverKey, err = server.WriteFiles(dir, "server"); err != nil {
+		t.Fatal(err)
+	}
+	if pki.clientCert, pki.clientKey, err = client.WriteFiles(dir, "client"); err != nil {
+		t.Fatal(err)
+	}
+
+	return pki
+}
+
+// serve starts a server that only exposes the health service with the given TLS settings.
+func serve(t *testing.T, cfg config.TLS) string {
+	t.Helper()
+
+	tlsConfig, err := ServerConfig(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ServerConfig: %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(ServerOptions(tlsConfig)...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	return lis.Addr().String()
+}
+
+func check(t *testing.T, addr string, cfg config.TLS) error {
+	t.Helper()
+
+	transport, err := DialOption(cfg)
+	if err != nil {
+		t.Fatalf("DialOption: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, transport)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	pki := newTestPKI(t, t.TempDir())
+	other := newTestPKI(t, t.TempDir())
+
+	addr := serve(t, config.TLS{
+		Enabled:    true,
+		CertFile:   pki.serverCert,
+		KeyFile:    pki.serverKey,
+		CAFile:     pki.caFile,
+		ClientAuth: true,
+	})
+
+	if err := check(t, addr, config.TLS{
+		Enabled:  true,
+		CAFile:   pki.caFile,
+		CertFile: pki.clientCert,
+		KeyFile:  pki.clientKey,
+	}); err != nil {
+		t.Fatalf("client with a trusted certificate was rejected: %v", err)
+	}
+
+	if err := check(t, addr, config.TLS{Enabled: true, CAFile: pki.caFile}); err == nil {
+		t.Fatalf("client without a certificate was accepted")
+	}
+
+	if err := check(t, addr, config.TLS{
+		Enabled:  true,
+		CAFile:   pki.caFile,
+		CertFile: other.clientCert,
+		KeyFile:  other.clientKey,
+	}); err == nil {
+		t.Fatalf("client with a certificate from another CA was accepted")
+	}
+}
+
+func TestServerOnlyTLS(t *testing.T) {
+	pki := newTestPKI(t, t.TempDir())
+	other := newTestPKI(t, t.TempDir())
+
+	addr := serve(t, config.TLS{Enabled: true, CertFile: pki.serverCert, KeyFile: pki.serverKey})
+
+	if err := check(t, addr, config.TLS{Enabled: true, CAFile: pki.caFile}); err != nil {
+		t.Fatalf("client trusting the server CA was rejected: %v", err)
+	}
+	if err := check(t, addr, config.TLS{Enabled: true, CAFile: other.caFile}); err == nil {
+		t.Fatalf("client accepted a server certificate from an untrusted CA")
+	}
+	if err := check(t, addr, config.TLS{}); err == nil {
+		t.Fatalf("plaintext client talked to a TLS server")
+	}
+}
+
+func TestServerConfigWithoutTLS(t *testing.T) {
+	tlsConfig, err := ServerConfig(context.Background(), config.TLS{CertFile: "missing.pem", KeyFile: "missing.key"})
+	if err != nil || tlsConfig != nil {
+		t.Fatalf("ServerConfig with TLS disabled returned %v, %v; want nil, nil", tlsConfig, err)
+	}
+	if opts := ServerOptions(nil); len(opts) != 0 {
+		t.Errorf("ServerOptions(nil) returned %d options, want none", len(opts))
+	}
+}
@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"io"
+	"sync"
+)
+
+// clientStream records message events and ends the call span once the
+// stream is over.
+type clientStream struct {
+	grpc.ClientStream
+	desc *grpc.StreamDesc
+	span trace.Span
+	// done is closed when the span ends.
+	done chan struct{}
+
+	mu       sync.Mutex
+	sent     int
+	received int
+	ended    bool
+}
+
+// newClientStream wraps cs, whose call runs under ctx. Callers that give up on
+// a stream cancel ctx rather than read it to the end, so the span also ends
+// when ctx is done.
+func newClientStream(ctx context.Context, cs grpc.ClientStream, desc *grpc.StreamDesc, span trace.Span) *clientStream {
+	s := &clientStream{ClientStream: cs, desc: desc, span: span, done: make(chan struct{})}
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.end(status.FromContextError(ctx.Err()).Err())
+		case <-s.done:
+		}
+	}()
+
+	return s
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil {
+		// The real status is returned by RecvMsg.
+		return err
+	}
+
+	s.mu.Lock()
+	s.sent++
+	id := s.sent
+	s.mu.Unlock()
+	messageEvent(s.span, semconv.MessageTypeSent, id)
+
+	return nil
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == io.EOF:
+		s.end(nil)
+	case err != nil:
+		s.end(err)
+	default:
+		s.mu.Lock()
+		s.received++
+		id := s.received
+		s.mu.Unlock()
+		messageEvent(s.span, semconv.MessageTypeReceived, id)
+
+		if !s.desc.ServerStreams {
+			// Client streams get exactly one response, there is no EOF to wait for.
+			s.end(nil)
+		}
+	}
+
+	return err
+}
+
+func (s *clientStream) end(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ended {
+		return
+	}
+	s.ended = true
+	close(s.done)
+	setStatus(s.span, err)
+	s.span.End()
+}
@@ -0,0 +1,160 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"os"
+	"sync"
+)
+
+// NewFileExporter returns an exporter appending one ExportTraceServiceRequest
+// per batch to path, one OTLP/JSON document per line. Tests read the file back
+// with ReadFile.
+func NewFileExporter(ctx context.Context, path string) (*otlptrace.Exporter, error) {
+	return otlptrace.New(ctx, &fileClient{path: path})
+}
+
+// ReadFile parses a file written by the file exporter.
+func ReadFile(path string) ([]*tracepb.ResourceSpans, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spans []*tracepb.ResourceSpans
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var req coltracepb.ExportTraceServiceRequest
+		line, err := convertIDs(line, hexToBase64)
+		if err == nil {
+			err = protojson.Unmarshal(line, &req)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, i+1, err)
+		}
+		spans = append(spans, req.GetResourceSpans()...)
+	}
+
+	return spans, nil
+}
+
+// fileClient is an otlptrace.Client that writes to a file instead of a collector.
+type fileClient struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+func (c *fileClient) Start(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, err := os.OpenFile(c.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening trace file: %w", err)
+	}
+	c.file = f
+
+	return nil
+}
+
+func (c *fileClient) Stop(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return nil
+	}
+	err := c.file.Close()
+	c.file = nil
+
+	return err
+}
+
+func (c *fileClient) UploadTraces(_ context.Context, spans []*tracepb.ResourceSpans) error {
+	data, err := protojson.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: spans})
+	if err == nil {
+		data, err = convertIDs(data, base64ToHex)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return fmt.Errorf("trace file %s is closed", c.path)
+	}
+	_, err = c.file.Write(append(data, '\n'))
+
+	return err
+}
+
+// idFields are the JSON names of the trace and span ids, which OTLP/JSON
+// writes in hex where the protobuf JSON mapping of bytes is base64.
+var idFields = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+// convertIDs rewrites every id of the JSON document data with convert.
+func convertIDs(data []byte, convert func(string) (string, error)) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep numbers as written, such as 64-bit integers
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var walk func(v interface{}) error
+	walk = func(v interface{}) error {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, value := range v {
+				if id, ok := value.(string); ok && idFields[key] {
+					converted, err := convert(id)
+					if err != nil {
+						return fmt.Errorf("%s: %w", key, err)
+					}
+					v[key] = converted
+					continue
+				}
+				if err := walk(value); err != nil {
+					return err
+				}
+			}
+		case []interface{}:
+			for _, value := range v {
+				if err := walk(value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk(doc); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+func base64ToHex(id string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(id)
+	return hex.EncodeToString(raw), err
+}
+
+func hexToBase64(id string) (string, error) {
+	raw, err := hex.DecodeString(id)
+	return base64.StdEncoding.EncodeToString(raw), err
+}
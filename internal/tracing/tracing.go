@@ -0,0 +1,262 @@
+// Package tracing creates OpenTelemetry spans for gRPC calls on both ends of a
+// connection. The client interceptors inject the W3C trace context into the
+// outgoing metadata and the server interceptors continue the trace from it, so
+// a slow call shows up as one tree of client span, server span and whatever
+// the handler records below it.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"grpc-go-course/internal/config"
+	"grpc-go-course/internal/logging"
+	"os"
+	"strings"
+)
+
+const instrumentationName = "grpc-go-course/internal/tracing"
+
+// Tracing holds the tracer used by the interceptors and the provider behind it.
+type Tracing struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	shutdown   func(context.Context) error
+}
+
+// New creates interceptors recording spans with provider and propagating them
+// as W3C trace context.
+func New(provider trace.TracerProvider) *Tracing {
+	return &Tracing{
+		tracer:     provider.Tracer(instrumentationName),
+		propagator: propagation.TraceContext{},
+		shutdown:   func(context.Context) error { return nil },
+	}
+}
+
+// Setup creates the exporter selected by cfg. With the none exporter spans are
+// not recorded, but trace context received from a caller is still passed on.
+func Setup(ctx context.Context, cfg config.Tracing, serviceName string) (*Tracing, error) {
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case "", "none":
+		return New(noop.NewTracerProvider()), nil
+	case "stdout":
+		e, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, err
+		}
+		exporter = e
+	case "otlp-file":
+		e, err := NewFileExporter(ctx, cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		exporter = e
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	t := New(provider)
+	t.shutdown = provider.Shutdown
+
+	return t, nil
+}
+
+// Shutdown flushes the spans that have not been exported yet.
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	return t.shutdown(ctx)
+}
+
+// ServerOptions returns the server interceptors. They should come after the
+// logging interceptors so that call logs carry the trace id.
+func (t *Tracing) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(t.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(t.StreamServerInterceptor),
+	}
+}
+
+// DialOptions returns the client interceptors.
+func (t *Tracing) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(t.UnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(t.StreamClientInterceptor),
+	}
+}
+
+// UnaryServerInterceptor implements grpc.UnaryServerInterceptor.
+func (t *Tracing) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := t.startServerSpan(ctx, info.FullMethod)
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+	setStatus(span, err)
+
+	return resp, err
+}
+
+// StreamServerInterceptor implements grpc.StreamServerInterceptor. Every
+// message sent or received is recorded as an event of the call span.
+func (t *Tracing) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := t.startServerSpan(ss.Context(), info.FullMethod)
+	defer span.End()
+
+	err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx, span: span})
+	setStatus(span, err)
+
+	return err
+}
+
+// UnaryClientInterceptor implements grpc.UnaryClientInterceptor.
+func (t *Tracing) UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := t.startClientSpan(ctx, method)
+	defer span.End()
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	setStatus(span, err)
+
+	return err
+}
+
+// StreamClientInterceptor implements grpc.StreamClientInterceptor. The span
+// ends when the stream reports its final status through RecvMsg, or when the
+// context of the call is done.
+func (t *Tracing) StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, span := t.startClientSpan(ctx, method)
+
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		setStatus(span, err)
+		span.End()
+		return nil, err
+	}
+
+	return newClientStream(ctx, cs, desc, span), nil
+}
+
+func (t *Tracing) startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = t.propagator.Extract(ctx, metadataCarrier(md))
+
+	ctx, span := t.tracer.Start(ctx, spanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(rpcAttributes(fullMethod)...),
+	)
+	if sc := span.SpanContext(); sc.IsValid() {
+		ctx = logging.With(ctx, "trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+
+	return ctx, span
+}
+
+func (t *Tracing) startClientSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	ctx, span := t.tracer.Start(ctx, spanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(rpcAttributes(fullMethod)...),
+	)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	t.propagator.Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// spanName follows the OpenTelemetry convention of "package.Service/Method".
+func spanName(fullMethod string) string {
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	if parts := strings.SplitN(spanName(fullMethod), "/", 2); len(parts) == 2 {
+		attrs = append(attrs, semconv.RPCService(parts[0]), semconv.RPCMethod(parts[1]))
+	}
+
+	return attrs
+}
+
+func setStatus(span trace.Span, err error) {
+	s := status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(s.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, s.Message())
+	}
+}
+
+// messageEvent records one stream message, numbered from 1 in each direction.
+func messageEvent(span trace.Span, typ attribute.KeyValue, id int) {
+	span.AddEvent("message", trace.WithAttributes(typ, semconv.MessageIDKey.Int(id)))
+}
+
+// metadataCarrier lets a propagator read and write gRPC metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	span     trace.Span
+	sent     int
+	received int
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+		messageEvent(s.span, semconv.MessageTypeSent, s.sent)
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received++
+		messageEvent(s.span, semconv.MessageTypeReceived, s.received)
+	}
+	return err
+}
@@ -0,0 +1,3 @@
+#!/bin/bash
+
+protoc -I . internal/validate/validatepb/validate.proto --go_out=module=grpc-go-course:.
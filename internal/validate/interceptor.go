@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"grpc-go-course/internal/logging"
+	"log/slog"
+)
+
+// Options configures an Interceptor.
+type Options struct {
+	// Rejected, when set, is called with every message the rules reject and
+	// the error returned for it, for instance to count rejections.
+	Rejected func(ctx context.Context, method string, m proto.Message, err error)
+}
+
+// Interceptor validates every request message, those of client streams too,
+// before it reaches the handler.
+type Interceptor struct {
+	opts Options
+}
+
+// NewInterceptor creates an Interceptor.
+func NewInterceptor(opts Options) *Interceptor {
+	return &Interceptor{opts: opts}
+}
+
+// ServerOptions returns the interceptors that validate requests.
+func (i *Interceptor) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(i.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(i.StreamServerInterceptor),
+	}
+}
+
+// UnaryServerInterceptor rejects invalid requests with codes.InvalidArgument.
+func (i *Interceptor) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := i.check(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor makes every RecvMsg of the handler fail with
+// codes.InvalidArgument on an invalid message.
+func (i *Interceptor) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingStream{ServerStream: ss, interceptor: i, method: info.FullMethod})
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+	interceptor *Interceptor
+	method      string
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.interceptor.check(s.Context(), s.method, m)
+}
+
+func (i *Interceptor) check(ctx context.Context, method string, req interface{}) error {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	err := Message(m)
+	if err == nil {
+		return nil
+	}
+
+	logging.FromContext(ctx).Info("rejected invalid request", slog.String("validate_method", method), slog.Any("error", err))
+	if i.opts.Rejected != nil {
+		i.opts.Rejected(ctx, method, m, err)
+	}
+	return err
+}
@@ -0,0 +1,274 @@
+// Package validate checks messages against the rules declared with the options
+// of validatepb/validate.proto, and rejects the calls whose requests break them
+// before their handlers run.
+//
+// Violations name fields by their proto path, such as greeting.first_name,
+// percentiles[2] or variables["x"], and come back as an InvalidArgument status
+// with a BadRequest detail.
+//
+// Handlers must not rely on the interceptor for inputs that would make them
+// loop, allocate or compute without bound, or answer NaN: servers built
+// without it, such as test servers, call them with anything. They check those
+// inputs again with the bounds Rules reads from the same declarations.
+package validate
+
+import (
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"grpc-go-course/internal/rpcerr"
+	"grpc-go-course/internal/validate/validatepb"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// Domain is the ErrorInfo domain of the errors of this package.
+	Domain = "validate.grpc-go-course"
+	// ReasonInvalidRule is the ErrorInfo reason of the Internal error returned
+	// when a declared rule cannot be applied, such as a malformed pattern.
+	ReasonInvalidRule = "INVALID_RULE"
+)
+
+// Message returns nil when m follows its rules, and otherwise an InvalidArgument
+// error listing every violation.
+func Message(m proto.Message) error {
+	c := &checker{}
+	c.message("", m.ProtoReflect())
+	if c.ruleErr != nil {
+		return rpcerr.Internal(Domain, ReasonInvalidRule, nil, "%v", c.ruleErr)
+	}
+	if len(c.violations) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, len(c.violations))
+	for i, violation := range c.violations {
+		descriptions[i] = violation.GetField() + ": " + violation.GetDescription()
+	}
+	return rpcerr.BadRequest(codes.InvalidArgument, "invalid "+string(m.ProtoReflect().Descriptor().Name())+": "+strings.Join(descriptions, "; "), c.violations...)
+}
+
+type checker struct {
+	violations []*errdetails.BadRequest_FieldViolation
+	// ruleErr is the first rule that could not be applied.
+	ruleErr error
+}
+
+func (c *checker) violate(path, format string, args ...interface{}) {
+	c.violations = append(c.violations, rpcerr.Field(path, fmt.Sprintf(format, args...)))
+}
+
+func (c *checker) message(prefix string, m protoreflect.Message) {
+	desc := m.Descriptor()
+
+	oneofs := desc.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		oneof := oneofs.Get(i)
+		if oneof.IsSynthetic() || !proto.GetExtension(oneof.Options(), validatepb.E_Required).(bool) {
+			continue
+		}
+		if m.WhichOneof(oneof) == nil {
+			var names []string
+			for j := 0; j < oneof.Fields().Len(); j++ {
+				names = append(names, string(oneof.Fields().Get(j).Name()))
+			}
+			c.violate(join(prefix, string(oneof.Name())), "one of %s is required", strings.Join(names, ", "))
+		}
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		c.field(join(prefix, string(fd.Name())), m, fd, rulesOf(fd))
+	}
+}
+
+func (c *checker) field(path string, m protoreflect.Message, fd protoreflect.FieldDescriptor, rules *validatepb.FieldRules) {
+	switch {
+	case fd.IsList():
+		list := m.Get(fd).List()
+		c.count(path, list.Len(), rules)
+		for i := 0; i < list.Len(); i++ {
+			c.value(fmt.Sprintf("%s[%d]", path, i), fd, list.Get(i), rules)
+		}
+	case fd.IsMap():
+		entries := m.Get(fd).Map()
+		c.count(path, entries.Len(), rules)
+		entries.Range(func(key protoreflect.MapKey, v protoreflect.Value) bool {
+			c.value(fmt.Sprintf("%s[%q]", path, key.String()), fd.MapValue(), v, rules)
+			return true
+		})
+	case fd.HasPresence() && !m.Has(fd):
+		if rules.GetRequired() {
+			c.violate(path, "is required")
+		}
+	default:
+		v := m.Get(fd)
+		if rules.GetRequired() && !fd.HasPresence() && v.Equal(fd.Default()) {
+			c.violate(path, "is required")
+			return
+		}
+		c.value(path, fd, v, rules)
+	}
+}
+
+// count checks the rules on the number of elements of lists and maps.
+func (c *checker) count(path string, n int, rules *validatepb.FieldRules) {
+	if rules.GetRequired() && n == 0 {
+		c.violate(path, "is required")
+	}
+	if rules.MaxItems != nil && n > int(rules.GetMaxItems()) {
+		c.violate(path, "must have at most %d elements, got %d", rules.GetMaxItems(), n)
+	}
+}
+
+// value checks a single value, an element of fd when fd is a list or a map.
+func (c *checker) value(path string, fd protoreflect.FieldDescriptor, v protoreflect.Value, rules *validatepb.FieldRules) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if fd.Message().FullName() == "google.protobuf.Duration" {
+			c.duration(path, v.Message(), rules)
+			return
+		}
+		if !rules.GetSkip() {
+			c.message(path, v.Message())
+		}
+	case protoreflect.StringKind:
+		c.text(path, v.String(), rules)
+	case protoreflect.EnumKind:
+		if rules.GetDefined() && fd.Enum().Values().ByNumber(v.Enum()) == nil {
+			c.violate(path, "%d is not a %s", v.Enum(), fd.Enum().Name())
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		c.number(path, v.Float(), rules)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		c.number(path, float64(v.Int()), rules)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		c.number(path, float64(v.Uint()), rules)
+	}
+}
+
+func (c *checker) number(path string, v float64, rules *validatepb.FieldRules) {
+	if rules.GetFinite() && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		c.violate(path, "must be a finite number, got %v", v)
+		return
+	}
+	// written so that NaN is out of range, and reported once
+	if rules.Min != nil && !(v >= rules.GetMin()) {
+		c.violate(path, "must be at least %v, got %v", rules.GetMin(), v)
+		return
+	}
+	if rules.Max != nil && !(v <= rules.GetMax()) {
+		c.violate(path, "must be at most %v, got %v", rules.GetMax(), v)
+	}
+}
+
+func (c *checker) duration(path string, m protoreflect.Message, rules *validatepb.FieldRules) {
+	fields := m.Descriptor().Fields()
+	d := &durationpb.Duration{
+		Seconds: m.Get(fields.ByName("seconds")).Int(),
+		Nanos:   int32(m.Get(fields.ByName("nanos")).Int()),
+	}
+	if err := d.CheckValid(); err != nil {
+		c.violate(path, "is not a valid duration: %v", err)
+		return
+	}
+
+	seconds := float64(d.GetSeconds()) + float64(d.GetNanos())/1e9
+	if rules.Min != nil && seconds < rules.GetMin() {
+		c.violate(path, "must be at least %v, got %v", secondsToDuration(rules.GetMin()), d.AsDuration())
+	}
+	if rules.Max != nil && seconds > rules.GetMax() {
+		c.violate(path, "must be at most %v, got %v", secondsToDuration(rules.GetMax()), d.AsDuration())
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func (c *checker) text(path, s string, rules *validatepb.FieldRules) {
+	n := utf8.RuneCountInString(s)
+	if rules.MinLen != nil && n < int(rules.GetMinLen()) {
+		c.violate(path, "must be at least %d characters long, got %d", rules.GetMinLen(), n)
+	}
+	if rules.MaxLen != nil && n > int(rules.GetMaxLen()) {
+		c.violate(path, "must be at most %d characters long, got %d", rules.GetMaxLen(), n)
+	}
+	if rules.GetPattern() == "" {
+		return
+	}
+
+	pattern, err := compile(rules.GetPattern())
+	if err != nil {
+		if c.ruleErr == nil {
+			c.ruleErr = fmt.Errorf("pattern of %s: %v", path, err)
+		}
+		return
+	}
+	if !pattern.MatchString(s) {
+		c.violate(path, "must match %s", rules.GetPattern())
+	}
+}
+
+var (
+	fieldRules sync.Map // protoreflect.FieldDescriptor to *validatepb.FieldRules
+	patterns   sync.Map // string to *regexp.Regexp
+)
+
+// noRules stands for the rules of fields that declare none.
+var noRules = &validatepb.FieldRules{}
+
+// Rules returns the rules declared on the field name of m, which must not be
+// modified. It panics when m has no such field.
+func Rules(m proto.Message, name protoreflect.Name) *validatepb.FieldRules {
+	desc := m.ProtoReflect().Descriptor()
+	fd := desc.Fields().ByName(name)
+	if fd == nil {
+		panic(fmt.Sprintf("validate: %s has no field %s", desc.FullName(), name))
+	}
+	return rulesOf(fd)
+}
+
+// rulesOf returns the rules of fd, which must not be modified.
+func rulesOf(fd protoreflect.FieldDescriptor) *validatepb.FieldRules {
+	if cached, ok := fieldRules.Load(fd); ok {
+		return cached.(*validatepb.FieldRules)
+	}
+
+	r := noRules
+	if proto.HasExtension(fd.Options(), validatepb.E_Rules) {
+		r = proto.GetExtension(fd.Options(), validatepb.E_Rules).(*validatepb.FieldRules)
+	}
+	fieldRules.Store(fd, r)
+	return r
+}
+
+func compile(expr string) (*regexp.Regexp, error) {
+	if cached, ok := patterns.Load(expr); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(expr, re)
+	return re, nil
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,124 @@
+package validate
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"grpc-go-course/calculator/calculatorpb"
+	"grpc-go-course/greet/greetpb"
+	"grpc-go-course/internal/rpcerr"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		m    proto.Message
+		// fields is the sorted list of violated fields, none when valid
+		fields []string
+	}{
+		{"valid greeting", &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Jean-Luc", LastName: "O'Brien"}}, nil},
+		{"unset message", &greetpb.GreetRequest{}, []string{"greeting"}},
+		{"empty string", &greetpb.GreetRequest{Greeting: &greetpb.Greeting{LastName: "Lovelace"}}, []string{"greeting.first_name"}},
+		{"pattern", &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "R2D2", LastName: "-"}}, []string{"greeting.first_name", "greeting.last_name"}},
+		{"length in characters", &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: strings.Repeat("é", 100)}}, nil},
+		{"too long", &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: strings.Repeat("é", 101)}}, []string{"greeting.first_name"}},
+		{"minimum", &calculatorpb.SquareRootRequest{Number: -1}, []string{"number"}},
+		{"zero is a value", &calculatorpb.SquareRootRequest{}, nil},
+		{"maximum", &greetpb.GreetManyTimesRequest{Greeting: &greetpb.Greeting{FirstName: "Ada"}, Times: 1001}, []string{"times"}},
+		{"list elements", &calculatorpb.ComputeStatisticsRequest{Percentiles: []float64{50, 101, math.NaN(), -1}}, []string{"percentiles[1]", "percentiles[2]", "percentiles[3]"}},
+		{"finite", &calculatorpb.ComputeStatisticsRequest{Value: &calculatorpb.ComputeStatisticsRequest_DoubleValue{DoubleValue: math.Inf(1)}}, []string{"double_value"}},
+		{"required oneof", &calculatorpb.FindMaximumRequest{}, []string{"request"}},
+		{"nested message", &calculatorpb.FindMaximumRequest{Request: &calculatorpb.FindMaximumRequest_Config{Config: &calculatorpb.FindMaximumConfig{Mode: 42, K: 1001}}}, []string{"config.k", "config.mode"}},
+		{"negative duration", &greetpb.GreetManyTimesRequest{Greeting: &greetpb.Greeting{FirstName: "Ada"}, Interval: durationpb.New(-1)}, []string{"interval"}},
+		{"invalid duration", &greetpb.GreetManyTimesRequest{Greeting: &greetpb.Greeting{FirstName: "Ada"}, Interval: &durationpb.Duration{Seconds: 1, Nanos: -1}}, []string{"interval"}},
+		{"unset optional", &calculatorpb.DecimalSquareRootRequest{Number: "2"}, nil},
+		{"set optional", &calculatorpb.DecimalSquareRootRequest{Number: "2", Scale: proto.Int32(-1)}, []string{"scale"}},
+		{"required enum", &calculatorpb.DecimalArithmeticRequest{A: "1", B: "2"}, []string{"operation"}},
+		{"skipped message", &calculatorpb.BatchCalculateRequest{Operations: []*calculatorpb.BatchOperation{
+			{Operation: &calculatorpb.BatchOperation_SquareRoot{SquareRoot: &calculatorpb.SquareRootRequest{Number: -1}}},
+		}}, nil},
+	}
+
+	for _, tt := range tests {
+		err := Message(tt.m)
+		if tt.fields == nil {
+			if err != nil {
+				t.Errorf("%s: Message returned %v", tt.name, err)
+			}
+			continue
+		}
+
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: Message returned %v, want InvalidArgument", tt.name, err)
+			continue
+		}
+		var fields []string
+		for _, violation := range rpcerr.FieldViolations(err) {
+			fields = append(fields, violation.GetField())
+		}
+		sort.Strings(fields)
+		if strings.Join(fields, " ") != strings.Join(tt.fields, " ") {
+			t.Errorf("%s: violations of %v, want %v (%v)", tt.name, fields, tt.fields, err)
+		}
+	}
+}
+
+func TestMessageDescribesTheViolations(t *testing.T) {
+	err := Message(&calculatorpb.SquareRootRequest{Number: -2})
+
+	want := "invalid SquareRootRequest: number: must be at least 0, got -2"
+	if got := status.Convert(err).Message(); got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+}
+
+func TestInterceptorRejectsBeforeTheHandler(t *testing.T) {
+	var rejected []string
+	i := NewInterceptor(Options{Rejected: func(_ context.Context, method string, _ proto.Message, err error) {
+		rejected = append(rejected, method)
+	}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/calculator.CalculatorService/SquareRoot"}
+	handled := 0
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		handled++
+		return &calculatorpb.SquareRootResponse{}, nil
+	}
+
+	if _, err := i.UnaryServerInterceptor(context.Background(), &calculatorpb.SquareRootRequest{Number: 4}, info, handler); err != nil {
+		t.Errorf("a valid request was rejected: %v", err)
+	}
+	_, err := i.UnaryServerInterceptor(context.Background(), &calculatorpb.SquareRootRequest{Number: -4}, info, handler)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("an invalid request returned %v", err)
+	}
+
+	if handled != 1 {
+		t.Errorf("the handler ran %d times, want once", handled)
+	}
+	if len(rejected) != 1 || rejected[0] != info.FullMethod {
+		t.Errorf("Rejected was called for %v", rejected)
+	}
+}
+
+func TestRules(t *testing.T) {
+	if got := Rules(&greetpb.GreetManyTimesRequest{}, "times").GetMax(); got != 1000 {
+		t.Errorf("max of times = %v, want 1000", got)
+	}
+	if got := Rules(&calculatorpb.SumRequest{}, "first_number"); got.Max != nil || got.Min != nil {
+		t.Errorf("first_number declares no rules, got %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Rules of an unknown field did not panic")
+		}
+	}()
+	Rules(&greetpb.GreetManyTimesRequest{}, "count")
+}
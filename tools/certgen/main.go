@@ -0,0 +1,63 @@
+// Command certgen writes a local CA plus a server and a client certificate
+// signed by it, so that the servers can be run with TLS or mutual TLS without
+// any external PKI:
+//
+//	go run ./tools/certgen -out certs -hosts localhost,127.0.0.1
+package main
+
+import (
+	"flag"
+	"fmt"
+	"grpc-go-course/internal/certgen"
+	"log"
+	"strings"
+)
+
+func main() {
+	out := flag.String("out", "certs", "directory the PEM files are written to")
+	hosts := flag.String("hosts", "localhost,127.0.0.1,::1", "comma separated DNS names and IPs of the server certificate")
+	clientName := flag.String("client-cn", "client", "common name of the client certificate")
+	validity := flag.Duration("validity", certgen.DefaultValidity, "how long the leaf certificates stay valid")
+	flag.Parse()
+
+	ca, err := certgen.NewCA("grpc-go-course local CA")
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	caFile, _, err := ca.WriteFiles(*out, "ca")
+	if err != nil {
+		log.Fatalf("error while writing CA: %v", err)
+	}
+
+	serverCert, err := ca.Issue(certgen.LeafOptions{
+		CommonName: "server",
+		Hosts:      strings.Split(*hosts, ","),
+		Validity:   *validity,
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	serverFile, serverKey, err := serverCert.WriteFiles(*out, "server")
+	if err != nil {
+		log.Fatalf("error while writing server certificate: %v", err)
+	}
+
+	clientCert, err := ca.Issue(certgen.LeafOptions{
+		CommonName: *clientName,
+		Client:     true,
+		Validity:   *validity,
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	clientFile, clientKey, err := clientCert.WriteFiles(*out, "client")
+	if err != nil {
+		log.Fatalf("error while writing client certificate: %v", err)
+	}
+
+	fmt.Printf("CA:     %s\n", caFile)
+	fmt.Printf("server: %s %s\n", serverFile, serverKey)
+	fmt.Printf("client: %s %s\n", clientFile, clientKey)
+	fmt.Printf("\nserver flags: -tls-enabled -tls-cert %s -tls-key %s -tls-ca %s -tls-client-auth\n", serverFile, serverKey, caFile)
+	fmt.Printf("client flags: -tls-enabled -tls-ca %s -tls-cert %s -tls-key %s\n", caFile, clientFile, clientKey)
+}